@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"sync"
+
+	"github.com/WebChads/AccountService/internal/blob"
+	"github.com/WebChads/AccountService/internal/config"
+	server "github.com/WebChads/AccountService/internal/delivery/http"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
+	"github.com/WebChads/AccountService/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+)
+
+func accountCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "account",
+		Usage: "manage accounts through service usecases",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "output format: table or json",
+				Value: formatTable,
+			},
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:      "get",
+				Usage:     "show account",
+				ArgsUsage: "USER_ID",
+				Action: withAccountUsecase(func(ctx context.Context, c *cli.Context, u *usecase.AccountUsecase) error {
+					userId, err := stringArg(c, "USER_ID")
+					if err != nil {
+						return err
+					}
+
+					account, err := u.Get(ctx, userId)
+					if err != nil {
+						return err
+					}
+
+					return printAccounts(c.String("format"), []domain.Account{*account})
+				}),
+			},
+			{
+				Name:  "create",
+				Usage: "create account",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{Name: "user-id", Usage: "account user id", Required: true},
+				}, accountFieldFlags()...),
+				Action: withAccountUsecase(func(ctx context.Context, c *cli.Context, u *usecase.AccountUsecase) error {
+					userId, err := uuid.Parse(c.String("user-id"))
+					if err != nil {
+						return fmt.Errorf("invalid user id: %w", err)
+					}
+
+					birthdate, err := optionalDate(c, "birthdate")
+					if err != nil {
+						return err
+					}
+
+					req := dtos.CreateAccountRequest{
+						UserId:      userId,
+						Firstname:   c.String("firstname"),
+						Surname:     c.String("surname"),
+						Patronymic:  c.String("patronymic"),
+						Gender:      c.String("gender"),
+						DisplayName: c.String("display-name"),
+						Bio:         c.String("bio"),
+						Locale:      c.String("locale"),
+						Timezone:    c.String("timezone"),
+						Country:     c.String("country"),
+						City:        c.String("city"),
+						Email:       c.String("email"),
+						Phone:       c.String("phone"),
+					}
+					if birthdate != nil {
+						req.Birthdate = *birthdate
+					}
+					account, err := accountFromRequest(u, req)
+					if err != nil {
+						return err
+					}
+					if err := u.Create(ctx, account); err != nil {
+						return err
+					}
+
+					return printGet(ctx, c, u, userId.String())
+				}),
+			},
+			{
+				Name:      "update",
+				Usage:     "update account, only given fields are changed",
+				ArgsUsage: "USER_ID",
+				Flags:     accountFieldFlags(),
+				Action: withAccountUsecase(func(ctx context.Context, c *cli.Context, u *usecase.AccountUsecase) error {
+					arg, err := stringArg(c, "USER_ID")
+					if err != nil {
+						return err
+					}
+
+					userId, err := uuid.Parse(arg)
+					if err != nil {
+						return fmt.Errorf("invalid user id: %w", err)
+					}
+
+					birthdate, err := optionalDate(c, "birthdate")
+					if err != nil {
+						return err
+					}
+
+					req := dtos.UpdateAccountRequest{
+						UserId:      userId,
+						Firstname:   optionalString(c, "firstname"),
+						Surname:     optionalString(c, "surname"),
+						Patronymic:  optionalString(c, "patronymic"),
+						Gender:      optionalString(c, "gender"),
+						Birthdate:   birthdate,
+						DisplayName: optionalString(c, "display-name"),
+						Bio:         optionalString(c, "bio"),
+						Locale:      optionalString(c, "locale"),
+						Timezone:    optionalString(c, "timezone"),
+						Country:     optionalString(c, "country"),
+						City:        optionalString(c, "city"),
+						Email:       optionalString(c, "email"),
+						Phone:       optionalString(c, "phone"),
+					}
+					if err := validateRequest(u, req); err != nil {
+						return err
+					}
+					changes, err := req.ToDomain(u.Genders())
+					if err != nil {
+						return err
+					}
+					if err := u.Update(ctx, changes); err != nil {
+						return err
+					}
+
+					return printGet(ctx, c, u, userId.String())
+				}),
+			},
+			{
+				Name:      "delete",
+				Usage:     "delete account",
+				ArgsUsage: "USER_ID",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "yes", Usage: "confirm deletion"},
+				},
+				Action: withAccountUsecase(func(ctx context.Context, c *cli.Context, u *usecase.AccountUsecase) error {
+					userId, err := stringArg(c, "USER_ID")
+					if err != nil {
+						return err
+					}
+
+					if !c.Bool("yes") {
+						return errors.New("deletion must be confirmed with --yes")
+					}
+
+					if err := u.Delete(ctx, userId); err != nil {
+						return err
+					}
+
+					fmt.Printf("account %s deleted\n", userId)
+					return nil
+				}),
+			},
+			{
+				Name:  "search",
+				Usage: "search accounts",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "surname", Usage: "surname prefix"},
+					&cli.StringFlag{Name: "firstname", Usage: "exact first name"},
+					&cli.StringFlag{Name: "gender", Usage: "gender code of catalog, e.g. M, F, N or U, or its alias"},
+					&cli.IntFlag{Name: "limit", Usage: "max number of accounts", Value: 50},
+					&cli.IntFlag{Name: "offset", Usage: "number of accounts to skip"},
+				},
+				Action: withAccountUsecase(func(ctx context.Context, c *cli.Context, u *usecase.AccountUsecase) error {
+					req := dtos.SearchAccountsRequest{
+						Surname:   c.String("surname"),
+						Firstname: c.String("firstname"),
+						Gender:    c.String("gender"),
+						Limit:     c.Int("limit"),
+						Offset:    c.Int("offset"),
+					}
+					if err := validateRequest(u, req); err != nil {
+						return err
+					}
+					filter, err := req.ToDomain(u.Genders())
+					if err != nil {
+						return err
+					}
+
+					accounts, err := u.Search(ctx, filter)
+					if err != nil {
+						return err
+					}
+
+					return printAccounts(c.String("format"), accounts)
+				}),
+			},
+			accountImportCommand(),
+			accountExportCommand(),
+		},
+	}
+}
+
+func accountFieldFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{Name: "firstname", Usage: "first name"},
+		&cli.StringFlag{Name: "surname", Usage: "surname"},
+		&cli.StringFlag{Name: "patronymic", Usage: "patronymic"},
+		&cli.StringFlag{Name: "gender", Usage: "gender code of catalog, e.g. M, F, N or U, or its alias"},
+		&cli.StringFlag{Name: "birthdate", Usage: "birth date, YYYY-MM-DD"},
+		&cli.StringFlag{Name: "display-name", Usage: "profile display name"},
+		&cli.StringFlag{Name: "bio", Usage: "profile bio"},
+		&cli.StringFlag{Name: "locale", Usage: "BCP 47 language tag, e.g. ru-RU"},
+		&cli.StringFlag{Name: "timezone", Usage: "IANA time zone, e.g. Europe/Moscow"},
+		&cli.StringFlag{Name: "country", Usage: "ISO 3166-1 alpha-2 country code, e.g. RU"},
+		&cli.StringFlag{Name: "city", Usage: "profile city"},
+		&cli.StringFlag{Name: "email", Usage: "email, changed contact must be verified again"},
+		&cli.StringFlag{Name: "phone", Usage: "phone in international format, e.g. +79991234567"},
+	}
+}
+
+// withAccountUsecase builds account usecase the same way server does
+func withAccountUsecase(action func(context.Context, *cli.Context, *usecase.AccountUsecase) error) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		cfg, logger, err := loadConfig(os.Stderr)
+		if err != nil {
+			return err
+		}
+
+		genders, err := loadGenders(cfg)
+		if err != nil {
+			return err
+		}
+
+		ctx := usecase.WithActor(context.Background(), "cli:"+currentUser())
+
+		db, err := server.NewDB(ctx, cfg.DatabaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to create database: %w", err)
+		}
+		defer db.Close()
+
+		// Store is only needed to remove avatar files of deleted account,
+		// so commands reading accounts work while it is unavailable
+		avatars := &lazyBlobStore{cfg: cfg.Avatars.Store}
+
+		repos := usecase.NewRepositories(db)
+		accountUsecase := usecase.NewAccountUsecase(repos.Account, avatars, genders, logger)
+
+		return action(ctx, c, accountUsecase)
+	}
+}
+
+// lazyBlobStore connects to blob store on first use
+type lazyBlobStore struct {
+	cfg   config.BlobStoreConfig
+	once  sync.Once
+	store blob.BlobStore
+	err   error
+}
+
+func (s *lazyBlobStore) get(ctx context.Context) (blob.BlobStore, error) {
+	s.once.Do(func() {
+		s.store, s.err = blob.NewStoreFromConfig(ctx, s.cfg)
+		if s.err != nil {
+			s.err = errors.New("failed to create avatar store: " + s.err.Error())
+		}
+	})
+
+	return s.store, s.err
+}
+
+func (s *lazyBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	store, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+	return store.Put(ctx, key, r, size, contentType)
+}
+
+func (s *lazyBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, blob.Info, error) {
+	store, err := s.get(ctx)
+	if err != nil {
+		return nil, blob.Info{}, err
+	}
+	return store.Get(ctx, key)
+}
+
+func (s *lazyBlobStore) Delete(ctx context.Context, key string) error {
+	store, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+	return store.Delete(ctx, key)
+}
+
+// validateRequest checks request with the rules server applies
+func validateRequest(u *usecase.AccountUsecase, request any) error {
+	return validation.NewAccountValidator(u.Genders()).Struct(request)
+}
+
+// accountFromRequest validates creation request and maps it to account
+func accountFromRequest(u *usecase.AccountUsecase, req dtos.CreateAccountRequest) (domain.Account, error) {
+	if err := validateRequest(u, req); err != nil {
+		return domain.Account{}, err
+	}
+
+	return req.ToDomain(u.Genders())
+}
+
+func printGet(ctx context.Context, c *cli.Context, u *usecase.AccountUsecase, userId string) error {
+	account, err := u.Get(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	return printAccounts(c.String("format"), []domain.Account{*account})
+}
+
+func optionalString(c *cli.Context, name string) *string {
+	if !c.IsSet(name) {
+		return nil
+	}
+
+	value := c.String(name)
+	return &value
+}
+
+func optionalDate(c *cli.Context, name string) (*dtos.Date, error) {
+	if !c.IsSet(name) {
+		return nil, nil
+	}
+
+	date, err := dtos.ParseDate(c.String(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return &date, nil
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+
+	return "unknown"
+}
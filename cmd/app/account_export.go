@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/pkg/bulk"
+	"github.com/WebChads/AccountService/internal/usecase"
+	"github.com/urfave/cli/v2"
+)
+
+func accountExportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "bulk export accounts to CSV, NDJSON or Parquet",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "output", Usage: "output file, stdout if empty"},
+			&cli.StringFlag{Name: "output-format", Usage: "csv, ndjson or parquet", Value: bulk.FormatCSV},
+			&cli.StringFlag{Name: "created-from", Usage: "include accounts created at or after (RFC 3339 or YYYY-MM-DD)"},
+			&cli.StringFlag{Name: "created-to", Usage: "include accounts created before (RFC 3339 or YYYY-MM-DD)"},
+			&cli.StringFlag{Name: "gender", Usage: "include accounts with gender"},
+			&cli.BoolFlag{Name: "mask-pii", Usage: "mask names and birth dates"},
+		},
+		Action: withAccountUsecase(func(ctx context.Context, c *cli.Context, u *usecase.AccountUsecase) error {
+			createdFrom, err := bulk.ParseTime(c.String("created-from"))
+			if err != nil {
+				return err
+			}
+			createdTo, err := bulk.ParseTime(c.String("created-to"))
+			if err != nil {
+				return err
+			}
+
+			req := dtos.ExportAccountsRequest{
+				CreatedFrom: createdFrom,
+				CreatedTo:   createdTo,
+				Gender:      c.String("gender"),
+				MaskPII:     c.Bool("mask-pii"),
+			}
+			if err := validateRequest(u, req); err != nil {
+				return err
+			}
+			filter, err := req.ToDomain(u.Genders())
+			if err != nil {
+				return err
+			}
+
+			var out io.Writer = os.Stdout
+			if path := c.String("output"); path != "" {
+				file, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				out = file
+			}
+
+			writer, err := bulk.NewWriter(out, c.String("output-format"))
+			if err != nil {
+				return err
+			}
+
+			exported, err := u.Export(ctx, filter, req.MaskPII, writer)
+			if err != nil {
+				return err
+			}
+
+			if err := writer.Close(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(os.Stderr, "exported %d accounts\n", exported)
+			return nil
+		}),
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/pkg/bulk"
+	"github.com/WebChads/AccountService/internal/usecase"
+	"github.com/urfave/cli/v2"
+)
+
+func accountImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "bulk import accounts from CSV or NDJSON file",
+		ArgsUsage: "FILE",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "input-format", Usage: "csv or ndjson, guessed by file extension if empty"},
+			&cli.IntFlag{Name: "batch-size", Usage: "rows per COPY batch", Value: 1000},
+			&cli.StringFlag{Name: "reject-file", Usage: "NDJSON file for rejected rows", Value: "rejects.ndjson"},
+			&cli.StringFlag{Name: "checkpoint", Usage: "checkpoint file, import resumes from it if exists"},
+		},
+		Action: withAccountUsecase(func(ctx context.Context, c *cli.Context, u *usecase.AccountUsecase) error {
+			path, err := stringArg(c, "FILE")
+			if err != nil {
+				return err
+			}
+
+			format := c.String("input-format")
+			if format == "" {
+				format = bulk.FormatFromPath(path)
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			reader, err := bulk.NewReader(file, format)
+			if err != nil {
+				return err
+			}
+
+			checkpointPath := c.String("checkpoint")
+			checkpoint, err := readCheckpoint(checkpointPath)
+			if err != nil {
+				return err
+			}
+			if checkpoint.LastLine > 0 {
+				fmt.Fprintf(os.Stderr, "resuming import after line %d\n", checkpoint.LastLine)
+			}
+
+			rejectFile, err := os.OpenFile(c.String("reject-file"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return err
+			}
+			defer rejectFile.Close()
+			rejects := json.NewEncoder(rejectFile)
+
+			result, err := u.Import(ctx, reader, usecase.ImportOptions{
+				BatchSize: c.Int("batch-size"),
+				SkipLines: checkpoint.LastLine,
+				OnReject: func(reject dtos.ImportReject) error {
+					return rejects.Encode(reject)
+				},
+				OnBatch: func(progress dtos.ImportResult) error {
+					// Totals include previous runs
+					progress.Processed += checkpoint.Processed
+					progress.Imported += checkpoint.Imported
+					progress.Rejected += checkpoint.Rejected
+
+					fmt.Fprintf(os.Stderr, "line %d: imported %d, rejected %d\n",
+						progress.LastLine, progress.Imported, progress.Rejected)
+
+					return writeCheckpoint(checkpointPath, progress)
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("processed: %d, imported: %d, rejected: %d, last line: %d\n",
+				result.Processed+checkpoint.Processed,
+				result.Imported+checkpoint.Imported,
+				result.Rejected+checkpoint.Rejected,
+				result.LastLine,
+			)
+			return nil
+		}),
+	}
+}
+
+func readCheckpoint(path string) (dtos.ImportResult, error) {
+	var checkpoint dtos.ImportResult
+	if path == "" {
+		return checkpoint, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return checkpoint, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return checkpoint, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+
+	return checkpoint, nil
+}
+
+// writeCheckpoint replaces checkpoint atomically so crash never leaves it half-written
+func writeCheckpoint(path string, progress dtos.ImportResult) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+)
+
+// intArg parses the first positional argument
+func intArg(c *cli.Context, name string) (int, error) {
+	if c.NArg() < 1 {
+		return 0, fmt.Errorf("%s requires argument %s", c.Command.Name, name)
+	}
+
+	n, err := strconv.Atoi(c.Args().First())
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, c.Args().First(), err)
+	}
+
+	return n, nil
+}
+
+// stringArg returns the first positional argument
+func stringArg(c *cli.Context, name string) (string, error) {
+	if c.NArg() < 1 {
+		return "", fmt.Errorf("%s requires argument %s", c.Command.Name, name)
+	}
+
+	return c.Args().First(), nil
+}
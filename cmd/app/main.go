@@ -1,16 +1,19 @@
 package main
 
 import (
-	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"os"
+	"slices"
+	// Time zone database for accounts time zones on hosts without it
+	_ "time/tzdata"
 
 	_ "github.com/WebChads/AccountService/docs"
 	"github.com/WebChads/AccountService/internal/config"
-	server "github.com/WebChads/AccountService/internal/delivery/http"
-	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
-	"github.com/WebChads/AccountService/internal/storage/pgsql/migrations"
+	"github.com/WebChads/AccountService/internal/models/domain"
 	prettylogger "github.com/WebChads/AccountService/pkg/pretty_logger"
+	"github.com/urfave/cli/v2"
 )
 
 // @title AccountService API
@@ -23,38 +26,61 @@ import (
 // @in header
 // @name Authorization
 func main() {
+	app := &cli.App{
+		Name:  "account-service",
+		Usage: "user accounts and personal info service",
+		// Run server if no command is given
+		Action: serveAction,
+		Commands: []*cli.Command{
+			serveCommand(),
+			migrateCommand(),
+			accountCommand(),
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+// loadConfig reads config and creates logger writing to out
+func loadConfig(out io.Writer) (*config.ServerConfig, *slog.Logger, error) {
 	// Init config
 	config := config.NewServerConfig()
 	if config == nil {
-		return
+		return nil, nil, errors.New("failed to load config")
 	}
 
 	// Init logger
-	logger := setupLogger(config.LogLevel)
+	logger := setupLogger(config.LogLevel, out)
 
-	// Create context
-	ctx := context.Background()
+	return config, logger, nil
+}
+
+// loadGenders builds gender catalog from config and returns genders allowed in accounts
+func loadGenders(cfg *config.ServerConfig) (domain.Genders, error) {
+	extra := make([]domain.Gender, 0, len(cfg.GenderCatalog))
+	for _, g := range cfg.GenderCatalog {
+		extra = append(extra, domain.NewGender(g.Code, g.Labels, g.Aliases))
+	}
 
-	// Init database
-	db, err := server.NewDB(ctx, config.DatabaseURL)
+	catalog, err := domain.NewGenderCatalog(extra...)
 	if err != nil {
-		logger.Error("failed to create database", slogerr.Error(err))
-		return
+		return nil, errors.New("invalid gender_catalog: " + err.Error())
 	}
-	defer db.Close()
 
-	// Apply migrations
-	if err := migrations.RunMigrations(db.DB, logger); err != nil {
-		return
+	genders, err := domain.AllowedGenders(catalog, cfg.Genders)
+	if err != nil {
+		return nil, errors.New("invalid genders: " + err.Error())
 	}
 
-	// Configure server
-	router := server.InitRouter(config, logger, db)
-	srv := server.NewServer(router, config.Address)
+	// Consumer creates accounts with undisclosed gender
+	if cfg.Consumer.Enabled && !slices.Contains(genders.Codes(), domain.GenderUndisclosed) {
+		return nil, errors.New("consumer requires gender " + domain.GenderUndisclosed + " to be allowed")
+	}
 
-	// Run server
-	logger.Info("server started", "address", config.Address)
-	srv.ListenAndServe()
+	return genders, nil
 }
 
 const (
@@ -63,24 +89,24 @@ const (
 	envProd  = "prod"
 )
 
-func setupLogger(env string) *slog.Logger {
+func setupLogger(env string, out io.Writer) *slog.Logger {
 	var log *slog.Logger
 
 	switch env {
 	case envLocal:
-		handler := prettylogger.NewPrettyHandler(os.Stdout)
+		handler := prettylogger.NewPrettyHandler(out)
 		log = slog.New(handler)
 	case envStage:
 		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug}),
 		)
 	case envProd:
 		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
+			slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelInfo}),
 		)
 	default: // If env config is invalid, set prod settings by default due to security
 		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
+			slog.NewJSONHandler(out, &slog.HandlerOptions{Level: slog.LevelInfo}),
 		)
 	}
 
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/config"
+	server "github.com/WebChads/AccountService/internal/delivery/http"
+	"github.com/WebChads/AccountService/internal/storage/pgsql/migrations"
+	"github.com/urfave/cli/v2"
+)
+
+func migrateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "manage database schema migrations",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "apply all pending migrations",
+				Action: withMigrator(func(c *cli.Context, m *migrations.Migrator) error {
+					return m.Up()
+				}),
+			},
+			{
+				Name:      "down",
+				Usage:     "roll back N migrations",
+				ArgsUsage: "N",
+				Action: withMigrator(func(c *cli.Context, m *migrations.Migrator) error {
+					n, err := intArg(c, "N")
+					if err != nil {
+						return err
+					}
+					return m.Down(n)
+				}),
+			},
+			{
+				Name:      "steps",
+				Usage:     "apply (N > 0) or roll back (N < 0) N migrations",
+				ArgsUsage: "N",
+				Action: withMigrator(func(c *cli.Context, m *migrations.Migrator) error {
+					n, err := intArg(c, "N")
+					if err != nil {
+						return err
+					}
+					return m.Steps(n)
+				}),
+			},
+			{
+				Name:      "goto",
+				Usage:     "migrate up or down to version V",
+				ArgsUsage: "V",
+				Action: withMigrator(func(c *cli.Context, m *migrations.Migrator) error {
+					v, err := intArg(c, "V")
+					if err != nil {
+						return err
+					}
+					if v < 0 {
+						return fmt.Errorf("version must not be negative, got %d", v)
+					}
+					return m.Goto(uint(v))
+				}),
+			},
+			{
+				Name:  "version",
+				Usage: "print current version",
+				Action: withMigrator(func(c *cli.Context, m *migrations.Migrator) error {
+					version, dirty, err := m.Version()
+					if err != nil {
+						return err
+					}
+					fmt.Printf("version: %d, dirty: %t\n", version, dirty)
+					return nil
+				}),
+			},
+			{
+				Name:  "plan",
+				Usage: "print pending migrations and their SQL without applying them",
+				Action: withMigrator(func(c *cli.Context, m *migrations.Migrator) error {
+					plan, err := m.Plan()
+					if err != nil {
+						return err
+					}
+					if len(plan) == 0 {
+						fmt.Println("no pending migrations")
+						return nil
+					}
+					for _, m := range plan {
+						fmt.Printf("-- %d %s\n%s\n\n", m.Version, m.Identifier, m.SQL)
+					}
+					return nil
+				}),
+			},
+			{
+				Name:      "force",
+				Usage:     "set version V without running migrations (clears dirty flag)",
+				ArgsUsage: "V",
+				Action: withMigrator(func(c *cli.Context, m *migrations.Migrator) error {
+					v, err := intArg(c, "V")
+					if err != nil {
+						return err
+					}
+					return m.Force(v)
+				}),
+			},
+		},
+	}
+}
+
+// withMigrator connects to database and passes migrator to action
+func withMigrator(action func(*cli.Context, *migrations.Migrator) error) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		cfg, logger, err := loadConfig(os.Stderr)
+		if err != nil {
+			return err
+		}
+
+		db, err := server.NewDB(context.Background(), cfg.DatabaseURL)
+		if err != nil {
+			return fmt.Errorf("failed to create database: %w", err)
+		}
+		defer db.Close()
+
+		migrator, err := migrations.NewMigrator(db.DB, migrationOptions(cfg), logger)
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		return action(c, migrator)
+	}
+}
+
+func migrationOptions(cfg *config.ServerConfig) migrations.Options {
+	return migrations.Options{
+		Dir:         cfg.MigrationsPath,
+		LockTimeout: time.Duration(cfg.MigrationLockTimeout),
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+func printAccounts(format string, accounts []domain.Account) error {
+	// Output has the same shape as HTTP responses
+	responses := dtos.NewGetAccountResponses(accounts, time.Now())
+
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(responses)
+	case formatTable:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "USER_ID\tFIRSTNAME\tSURNAME\tPATRONYMIC\tGENDER\tBIRTHDATE\tAGE")
+		for _, a := range responses {
+			// Unknown birthdate is shown as dash
+			birthdate, age := "-", "-"
+			if a.Birthdate != nil {
+				birthdate, age = a.Birthdate.String(), strconv.Itoa(*a.Age)
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				a.UserId, a.Firstname, a.Surname, a.Patronymic, a.Gender, birthdate, age,
+			)
+		}
+		return w.Flush()
+	}
+
+	return fmt.Errorf("unknown output format %q", format)
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/blob"
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/consumer"
+	server "github.com/WebChads/AccountService/internal/delivery/http"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/outbox"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/tracing"
+	storage "github.com/WebChads/AccountService/internal/storage/pgsql"
+	"github.com/WebChads/AccountService/internal/storage/pgsql/migrations"
+	"github.com/WebChads/AccountService/internal/stream"
+	"github.com/WebChads/AccountService/internal/usecase"
+	"github.com/WebChads/AccountService/internal/verification"
+	"github.com/WebChads/AccountService/internal/webhook"
+	"github.com/jmoiron/sqlx"
+	"github.com/urfave/cli/v2"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+func serveCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "serve",
+		Usage:  "run HTTP server",
+		Action: serveAction,
+	}
+}
+
+func serveAction(c *cli.Context) error {
+	config, logger, err := loadConfig(os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	genders, err := loadGenders(config)
+	if err != nil {
+		logger.Error("failed to load genders", slogerr.Error(err))
+		return err
+	}
+
+	// Create context, canceled on termination signal
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Init tracing
+	shutdownTracing, err := tracing.Setup(ctx, config.Tracing)
+	if err != nil {
+		logger.Error("failed to setup tracing", slogerr.Error(err))
+		return err
+	}
+	defer shutdownTracing(context.Background())
+
+	// Init database
+	db, err := server.NewDB(ctx, config.DatabaseURL)
+	if err != nil {
+		logger.Error("failed to create database", slogerr.Error(err))
+		return err
+	}
+	defer db.Close()
+
+	// Apply migrations
+	if !config.DisableAutoMigrate {
+		if err := migrations.RunMigrations(config.DatabaseURL, migrationOptions(config), logger); err != nil {
+			return err
+		}
+	} else {
+		logger.Info("auto-migration disabled, run \"migrate up\" to apply migrations")
+	}
+
+	// Account events stream follows outbox on every replica
+	broker := stream.NewBroker(config.Stream.LogSize, config.Stream.ClientBuffer)
+	tail := stream.NewTail(storage.NewOutboxRepository(db), broker, logger, stream.TailOptions{
+		PollInterval: time.Duration(config.Stream.PollInterval),
+		BatchSize:    config.Outbox.BatchSize,
+		LogSize:      config.Stream.LogSize,
+		GapTimeout:   time.Duration(config.Stream.GapTimeout),
+	})
+	go tail.Run(ctx)
+
+	// Run outbox relay
+	if config.Outbox.RelayEnabled {
+		closePublisher, err := startOutboxRelay(ctx, config, logger, db)
+		if err != nil {
+			logger.Error("failed to start outbox relay", slogerr.Error(err))
+			return err
+		}
+		defer closePublisher()
+	} else {
+		logger.Info("outbox relay disabled")
+	}
+
+	// Avatar files are kept in filesystem or S3-compatible storage
+	avatars, err := blob.NewStoreFromConfig(ctx, config.Avatars.Store)
+	if err != nil {
+		logger.Error("failed to create avatar store", slogerr.Error(err))
+		return err
+	}
+
+	// Run consumer of auth service events
+	if config.Consumer.Enabled {
+		startConsumer(ctx, config, logger, db, avatars, genders)
+	}
+
+	// Verification codes are sent to account contacts
+	sender, closeSender, err := verification.NewSenderFromConfig(config.Verification, logger)
+	if err != nil {
+		logger.Error("failed to create verification sender", slogerr.Error(err))
+		return err
+	}
+	defer closeSender()
+
+	// Configure server
+	router := server.InitRouter(config, logger, db, broker, sender, avatars, genders)
+	srv := server.NewServer(router, config)
+
+	// Run server
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+	logger.Info("server started", "address", config.Address)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Info("shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Stop(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func startOutboxRelay(ctx context.Context, cfg *config.ServerConfig, logger *slog.Logger,
+	db *sqlx.DB) (func() error, error) {
+	publisher, closePublisher, err := outbox.NewPublisherFromConfig(cfg.Outbox)
+	if err != nil {
+		return nil, err
+	}
+
+	publishers := []outbox.Publisher{publisher}
+
+	// Events are also turned into webhook deliveries
+	if cfg.Webhooks.Enabled {
+		webhooks := storage.NewWebhookRepository(db)
+		publishers = append(publishers, webhook.NewEnqueuer(webhooks))
+
+		dispatcher := webhook.NewDispatcher(webhooks, logger, webhook.DispatcherOptions{
+			PollInterval: time.Duration(cfg.Webhooks.PollInterval),
+			BatchSize:    cfg.Webhooks.BatchSize,
+			Timeout:      time.Duration(cfg.Webhooks.Timeout),
+			MaxAttempts:  cfg.Webhooks.MaxAttempts,
+			MinBackoff:   time.Duration(cfg.Webhooks.MinBackoff),
+			MaxBackoff:   time.Duration(cfg.Webhooks.MaxBackoff),
+		})
+		go dispatcher.Run(ctx)
+	}
+
+	relay := outbox.NewRelay(storage.NewOutboxRepository(db), outbox.NewMultiPublisher(publishers...), logger, outbox.RelayOptions{
+		PollInterval: time.Duration(cfg.Outbox.PollInterval),
+		BatchSize:    cfg.Outbox.BatchSize,
+		MinBackoff:   time.Duration(cfg.Outbox.MinBackoff),
+		MaxBackoff:   time.Duration(cfg.Outbox.MaxBackoff),
+	})
+	go relay.Run(ctx)
+
+	return closePublisher, nil
+}
+
+func startConsumer(ctx context.Context, cfg *config.ServerConfig, logger *slog.Logger, db *sqlx.DB,
+	avatars blob.BlobStore, genders domain.Genders) {
+	subscriber := consumer.NewFileSubscriber(cfg.Consumer.FilePath, time.Duration(cfg.Consumer.PollInterval))
+
+	accountUsecase := usecase.NewAccountUsecase(usecase.NewRepositories(db).Account, avatars, genders, logger)
+	store := storage.NewEventStore(db)
+
+	c := consumer.NewConsumer(subscriber, accountUsecase, store, store, logger, consumer.Options{
+		MaxAttempts:  cfg.Consumer.MaxAttempts,
+		RetryBackoff: time.Duration(cfg.Consumer.RetryBackoff),
+	})
+
+	go func() {
+		if err := c.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error("consumer failed", slogerr.Error(err))
+		}
+	}()
+}
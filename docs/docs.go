@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/account/create-account": {
-            "post": {
+        "/api/v1/account": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates a new user account with provided details",
+                "description": "Changes only fields present in request. Empty profile field or contact unsets it,\nchanged contact must be verified again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -32,27 +32,61 @@ const docTemplate = `{
                 "tags": [
                     "Account"
                 ],
-                "summary": "Create new account",
+                "summary": "Update own account",
                 "parameters": [
                     {
-                        "description": "Account creation data",
+                        "description": "Changed account fields",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.CreateAccountRequest"
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.UpdateAccountRequest"
                         }
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation messages",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
                         }
@@ -62,35 +96,46 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/account/get-account/{user_id}": {
-            "get": {
+        "/api/v1/account/avatar": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Returns account information for specified user ID",
+                "description": "Replaces avatar of current account. JPEG, PNG, GIF and WebP are accepted, type is detected from content.\nImage is cropped to center square and stored as thumbnails of configured sizes without EXIF and other metadata.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Account"
+                    "Avatar"
                 ],
-                "summary": "Get user account by ID",
+                "summary": "Upload avatar",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -98,7 +143,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.GetAccountResponse"
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.AvatarResponse"
                         }
                     },
                     "400": {
@@ -113,90 +158,2184 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
                         }
                     },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "github_com_WebChads_AccountService_internal_models_dtos.CreateAccountRequest": {
-            "type": "object",
-            "required": [
-                "birthdate",
-                "firstname",
-                "gender",
-                "surname"
-            ],
-            "properties": {
-                "birthdate": {
-                    "type": "string",
-                    "example": "1990-01-01"
-                },
-                "firstname": {
-                    "type": "string",
-                    "example": "Иван"
-                },
-                "gender": {
-                    "type": "string",
-                    "maxLength": 1,
-                    "minLength": 1,
-                    "example": "M"
-                },
-                "patronymic": {
-                    "type": "string",
-                    "example": "Иванович"
-                },
-                "surname": {
-                    "type": "string",
-                    "example": "Иванов"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes avatar of current account with all its thumbnails",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Avatar"
+                ],
+                "summary": "Delete avatar",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
                 }
             }
         },
-        "github_com_WebChads_AccountService_internal_models_dtos.GetAccountResponse": {
-            "type": "object",
-            "properties": {
-                "age": {
-                    "type": "integer",
-                    "example": 33
-                },
-                "birthdate": {
-                    "type": "string",
-                    "example": "1990-01-01T00:00:00Z"
-                },
-                "firstname": {
-                    "type": "string",
-                    "example": "Иван"
-                },
-                "gender": {
-                    "type": "string",
-                    "example": "male"
-                },
-                "patronymic": {
-                    "type": "string",
-                    "example": "Иванович"
-                },
-                "surname": {
-                    "type": "string",
-                    "example": "Иванов"
-                },
-                "user_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+        "/api/v1/account/avatars/{key}": {
+            "get": {
+                "description": "Serves avatar file by key from avatar URL, content never changes for the key",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "Avatar"
+                ],
+                "summary": "Get avatar thumbnail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Blob key, e.g. \u003cuser id\u003e/\u003cavatar id\u003e/128.jpg",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
                 }
             }
         },
-        "github_com_WebChads_AccountService_internal_models_dtos.Response": {
-            "type": "object",
-            "properties": {
-                "message": {},
-                "status": {
-                    "type": "integer",
-                    "example": 200
+        "/api/v1/account/birthdays": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns accounts with birthdays on days from..to inclusive in order of celebration.\nRange wraps year end and must not be longer than a year.\nThose born on February 29 celebrate on March 1 in non-leap years.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "List accounts with birthdays in date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "example": "2025-12-25",
+                        "description": "First day of range",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "example": "2026-01-07",
+                        "description": "Last day of range",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation messages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.BirthdayResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/account/create-account": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new user account with provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Create new account",
+                "parameters": [
+                    {
+                        "description": "Account creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.CreateAccountRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation messages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/account/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events stream of account.created, account.updated and account.deleted events.\nUsers receive only their own account events, admins receive all events unless user_id is set.\nReconnect with Last-Event-ID header (or last_event_id param) to resume; \"reset\" event\nmeans resume is not possible and account state must be reloaded.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Stream account changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Account to watch, defaults to own account for non-admins",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after event, same as Last-Event-ID header",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event stream, data of every event",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.AccountEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/account/genders": {
+            "get": {
+                "description": "Returns gender codes accepted in accounts with labels in requested language.\nLanguage is taken from lang parameter or Accept-Language header, English by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "List allowed genders",
+                "parameters": [
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Label language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label language",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.GenderResponse"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/account/get-account/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns account information for specified user ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "Get user account by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"550e8400-e29b-41d4-a716-446655440000\"",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.GetAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/account/verification/{channel}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Marks email or phone of current account verified if code matches the last one sent.\nCode is void after expiration or too many wrong attempts, new one must be requested then.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Verification"
+                ],
+                "summary": "Confirm verification code",
+                "parameters": [
+                    {
+                        "enum": [
+                            "email",
+                            "phone"
+                        ],
+                        "type": "string",
+                        "description": "Contact channel",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ConfirmVerificationRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation errors",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code, validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Contact is verified by another account",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Code expired, exhausted attempts or was not requested",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/account/verification/{channel}/send": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sends code to email or phone of current account, previous code of the channel becomes void.\nNew code may be requested after cooldown, see resend_at.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Verification"
+                ],
+                "summary": "Send verification code",
+                "parameters": [
+                    {
+                        "enum": [
+                            "email",
+                            "phone"
+                        ],
+                        "type": "string",
+                        "description": "Contact channel",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of message and validation errors",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.VerificationSentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Contact is not set or already verified",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Code was sent recently, see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/accounts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists accounts ordered by name. Names match case-insensitively in either\nCyrillic or Latin script, e.g. \"ivanov\" finds \"Иванов\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List accounts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ivan",
+                        "description": "Surname prefix",
+                        "name": "surname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Иван",
+                        "description": "First name",
+                        "name": "firstname",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gender",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation messages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.GetAccountResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/accounts/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams accounts matching filters as CSV, NDJSON or Parquet file",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.apache.parquet"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Bulk export accounts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "parquet"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339 or YYYY-MM-DD)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339 or YYYY-MM-DD)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Gender",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Mask names and birth dates",
+                        "name": "mask_pii",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation messages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/accounts/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams CSV or NDJSON rows through account validation and inserts them in batches.\nRejected rows are returned in response, use last_line as resume_from to continue interrupted import.\nOnly first 1000 rejects are returned, truncated is set then and rejected holds total count.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Bulk import accounts",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Input format",
+                        "name": "format",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1000,
+                        "description": "Rows per batch",
+                        "name": "batch_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skip rows up to this line",
+                        "name": "resume_from",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/accounts/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Finds accounts by any part of full name tolerating typos, in either Cyrillic or\nLatin script, e.g. \"ivnov ivan\" finds \"Иванов Иван\". Best matches come first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Search accounts by full name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ivnov ivan",
+                        "description": "Full name or its part",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation messages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.AccountMatchResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.WebhookSubscription"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Subscribes URL to account events. Deliveries are signed with HMAC-SHA256 of\n\"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\" using secret, sent in X-Webhook-Signature as \"sha256=\u003chex\u003e\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.CreateWebhookRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation messages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhooks/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Query webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "subscription_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "en",
+                            "ru"
+                        ],
+                        "type": "string",
+                        "description": "Language of validation messages",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation errors are localized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "message": {
+                                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhooks/deliveries/{delivery_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook delivery with attempt log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhooks/deliveries/{delivery_id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Schedules delivery for immediate resend with fresh retry budget",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Replay webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhooks/{webhook_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes webhook together with its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_WebChads_AccountService_internal_models_dtos.AccountEvent": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "description": "Account is state after change, only user_id is set for deleted account",
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "type": {
+                    "type": "string",
+                    "example": "account.updated"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.AccountMatchResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.GetAccountResponse"
+                },
+                "rank": {
+                    "description": "Rank is relevance of match, higher is better",
+                    "type": "number",
+                    "example": 0.83
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.AvatarResponse": {
+            "type": "object",
+            "properties": {
+                "uploaded_at": {
+                    "type": "string"
+                },
+                "urls": {
+                    "description": "URLs are square thumbnail URLs by side in pixels",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "128": "/api/v1/account/avatars/550e8400-e29b-41d4-a716-446655440000/6ba7b810-9dad-11d1-80b4-00c04fd430c8/128.jpg"
+                    }
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.BirthdayResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.GetAccountResponse"
+                },
+                "date": {
+                    "description": "Date is the day birthday is celebrated on, February 29 birthdays are on March 1 in non-leap years",
+                    "type": "string",
+                    "format": "date",
+                    "example": "2025-01-01"
+                },
+                "turns_age": {
+                    "description": "TurnsAge is the age account holder turns on Date",
+                    "type": "integer",
+                    "example": 35
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.ConfirmVerificationRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 10,
+                    "example": "123456"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.ContactResponse": {
+            "type": "object",
+            "properties": {
+                "value": {
+                    "type": "string",
+                    "example": "ivan@example.com"
+                },
+                "verified": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "verified_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.CreateAccountRequest": {
+            "type": "object",
+            "required": [
+                "birthdate",
+                "firstname",
+                "gender",
+                "surname"
+            ],
+            "properties": {
+                "bio": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Люблю горы и джаз"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "1990-01-01"
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "Москва"
+                },
+                "country": {
+                    "description": "Country is ISO 3166-1 alpha-2 code",
+                    "type": "string",
+                    "example": "RU"
+                },
+                "display_name": {
+                    "description": "Profile fields are optional",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Ваня"
+                },
+                "email": {
+                    "description": "Contacts are verified separately, see /api/v1/account/verification",
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "ivan@example.com"
+                },
+                "firstname": {
+                    "type": "string",
+                    "example": "Иван"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "M"
+                },
+                "locale": {
+                    "description": "Locale is BCP 47 language tag",
+                    "type": "string",
+                    "example": "ru-RU"
+                },
+                "patronymic": {
+                    "type": "string",
+                    "example": "Иванович"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+79991234567"
+                },
+                "surname": {
+                    "type": "string",
+                    "example": "Иванов"
+                },
+                "timezone": {
+                    "description": "Timezone is IANA time zone name, age is calculated in it. UTC if empty",
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "account.created",
+                        "account.updated"
+                    ]
+                },
+                "secret": {
+                    "description": "Secret signs deliveries with HMAC-SHA256, it is never returned back",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16,
+                    "example": "3f1c9a7e5b2d4f6a8c0e"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example.com/hooks/accounts"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.GenderResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "M"
+                },
+                "label": {
+                    "description": "Label is display name in requested language",
+                    "type": "string",
+                    "example": "Male"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.GetAccountResponse": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "Age and Birthdate are omitted if birthdate is unknown",
+                    "type": "integer",
+                    "example": 33
+                },
+                "avatar_urls": {
+                    "description": "AvatarURLs are square thumbnail URLs by side in pixels, omitted if avatar is not uploaded",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "128": "/api/v1/account/avatars/550e8400-e29b-41d4-a716-446655440000/6ba7b810-9dad-11d1-80b4-00c04fd430c8/128.jpg"
+                    }
+                },
+                "bio": {
+                    "type": "string",
+                    "example": "Люблю горы и джаз"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "1990-01-01"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Москва"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "RU"
+                },
+                "display_name": {
+                    "description": "Profile fields are omitted if not set",
+                    "type": "string",
+                    "example": "Ваня"
+                },
+                "email": {
+                    "description": "Contacts are omitted if not set",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ContactResponse"
+                        }
+                    ]
+                },
+                "firstname": {
+                    "type": "string",
+                    "example": "Иван"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "M"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "ru-RU"
+                },
+                "patronymic": {
+                    "type": "string",
+                    "example": "Иванович"
+                },
+                "phone": {
+                    "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ContactResponse"
+                },
+                "surname": {
+                    "type": "string",
+                    "example": "Иванов"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.ImportReject": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Firstname is required"
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "record": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.ImportResult": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer",
+                    "example": 998
+                },
+                "last_line": {
+                    "description": "LastLine is the last processed line, pass it as resume_from to continue",
+                    "type": "integer",
+                    "example": 1000
+                },
+                "processed": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "rejects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ImportReject"
+                    }
+                },
+                "truncated": {
+                    "description": "Truncated is set when rejects hold only first of rejected rows",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.Response": {
+            "type": "object",
+            "properties": {
+                "message": {},
+                "request_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.UpdateAccountRequest": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Люблю горы и джаз"
+                },
+                "birthdate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "1990-01-01"
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "Москва"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "RU"
+                },
+                "display_name": {
+                    "description": "Empty string unsets profile field, timezone is reset to UTC",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Ваня"
+                },
+                "email": {
+                    "description": "Changed contact must be verified again, empty string unsets it",
+                    "type": "string",
+                    "maxLength": 254,
+                    "example": "ivan@example.com"
+                },
+                "firstname": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Иван"
+                },
+                "gender": {
+                    "type": "string",
+                    "example": "M"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "ru-RU"
+                },
+                "patronymic": {
+                    "type": "string",
+                    "example": "Иванович"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+79991234567"
+                },
+                "surname": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Иванов"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.ValidationError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "firstname"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "поле firstname обязательно"
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "required"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.ValidationErrors": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.ValidationError"
+                    }
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.VerificationSentResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "resend_at": {
+                    "description": "ResendAt is the earliest time new code may be requested",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Target is masked contact code was sent to",
+                    "type": "string",
+                    "example": "i***@example.com"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.WebhookAttempt": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "error": {
+                    "type": "string",
+                    "example": "unexpected status 502"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 502
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "account.updated"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "unexpected status 502"
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 502
+                },
+                "log": {
+                    "description": "Log is filled only for single delivery",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_WebChads_AccountService_internal_models_dtos.WebhookAttempt"
+                    }
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "failed"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_WebChads_AccountService_internal_models_dtos.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "account.created",
+                        "account.updated"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example.com/hooks/accounts"
                 }
             }
         }
@@ -0,0 +1,116 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+)
+
+// FSStore keeps blobs as files under root directory.
+// Content type is derived from key extension.
+type FSStore struct {
+	root string
+}
+
+func NewFSStore(root string) (*FSStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, errors.New("failed to create blob directory: " + err.Error())
+	}
+
+	return &FSStore{root: root}, nil
+}
+
+func (s *FSStore) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *FSStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return errors.New("failed to create blob directory: " + err.Error())
+	}
+
+	// File is renamed when complete, so readers never see partial content
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return errors.New("failed to create blob: " + err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return errors.New("failed to write blob: " + err.Error())
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.New("failed to write blob: " + err.Error())
+	}
+
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return errors.New("failed to write blob: " + err.Error())
+	}
+	if err = os.Rename(tmp.Name(), name); err != nil {
+		return errors.New("failed to write blob: " + err.Error())
+	}
+
+	return nil
+}
+
+func (s *FSStore) Get(_ context.Context, key string) (io.ReadCloser, Info, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, Info{}, err
+	}
+
+	file, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, Info{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, Info{}, errors.New("failed to open blob: " + err.Error())
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, Info{}, errors.New("failed to open blob: " + err.Error())
+	}
+	if stat.IsDir() {
+		file.Close()
+		return nil, Info{}, ErrNotFound
+	}
+
+	info := Info{
+		ContentType: mime.TypeByExtension(filepath.Ext(name)),
+		Size:        stat.Size(),
+	}
+
+	return file, info, nil
+}
+
+func (s *FSStore) Delete(_ context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errors.New("failed to delete blob: " + err.Error())
+	}
+
+	// Directory of deleted avatar version is left empty otherwise, error means it is not
+	os.Remove(filepath.Dir(name))
+
+	return nil
+}
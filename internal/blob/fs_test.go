@@ -0,0 +1,92 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFSStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	s, err := NewFSStore(root)
+	if err != nil {
+		t.Fatalf("NewFSStore() error = %v", err)
+	}
+
+	const key = "avatars/user/1/64.png"
+	if err = s.Put(ctx, key, strings.NewReader("png data"), 8, "image/png"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	r, info, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("read blob: %v", err)
+	}
+	if string(data) != "png data" || info.Size != 8 || info.ContentType != "image/png" {
+		t.Fatalf("Get() = %q, %+v, want png data", data, info)
+	}
+
+	// Temporary upload files are not left behind
+	entries, err := os.ReadDir(filepath.Join(root, "avatars/user/1"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("blob directory = %v, %v, want single file", entries, err)
+	}
+
+	if err = s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, err = s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+	if err = s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() of missing blob error = %v", err)
+	}
+
+	// Directory is not a blob
+	if _, _, err = s.Get(ctx, "avatars"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() of directory error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFSStoreRejectsKeysOutsideRoot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := NewFSStore(filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatalf("NewFSStore() error = %v", err)
+	}
+
+	secret := filepath.Join(dir, "secret")
+	if err = os.WriteFile(secret, []byte("secret"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+
+	if err = s.Put(ctx, "../escaped", strings.NewReader("x"), 1, ""); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put() error = %v, want ErrInvalidKey", err)
+	}
+	if _, _, err = s.Get(ctx, "../secret"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Get() error = %v, want ErrInvalidKey", err)
+	}
+	if err = s.Delete(ctx, "../secret"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Delete() error = %v, want ErrInvalidKey", err)
+	}
+
+	if _, err = os.Stat(secret); err != nil {
+		t.Errorf("file outside root is touched: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "escaped")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file is written outside root: %v", err)
+	}
+}
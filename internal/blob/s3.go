@@ -0,0 +1,99 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type S3Options struct {
+	// Endpoint is host:port of S3-compatible service, e.g. "localhost:9000" of MinIO
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	Region    string
+	UseSSL    bool
+}
+
+// S3Store keeps blobs in bucket of S3-compatible storage
+type S3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Store connects to storage and creates bucket if it does not exist
+func NewS3Store(ctx context.Context, opts S3Options) (*S3Store, error) {
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, errors.New("failed to create s3 client: " + err.Error())
+	}
+
+	exists, err := client.BucketExists(ctx, opts.Bucket)
+	if err != nil {
+		return nil, errors.New("failed to check s3 bucket: " + err.Error())
+	}
+	if !exists {
+		err = client.MakeBucket(ctx, opts.Bucket, minio.MakeBucketOptions{Region: opts.Region})
+		if err != nil {
+			return nil, errors.New("failed to create s3 bucket: " + err.Error())
+		}
+	}
+
+	return &S3Store{client: client, bucket: opts.Bucket}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return errors.New("failed to put blob: " + err.Error())
+	}
+
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, Info, error) {
+	if err := checkKey(key); err != nil {
+		return nil, Info{}, err
+	}
+
+	// Object is fetched lazily, so missing key is reported by Stat
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, Info{}, errors.New("failed to get blob: " + err.Error())
+	}
+
+	stat, err := object.Stat()
+	if err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, Info{}, ErrNotFound
+		}
+		return nil, Info{}, errors.New("failed to get blob: " + err.Error())
+	}
+
+	return object, Info{ContentType: stat.ContentType, Size: stat.Size}, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return errors.New("failed to delete blob: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/WebChads/AccountService/internal/config"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore keeps files by key, keys are slash separated relative paths
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get returns blob content, caller must close it
+	Get(ctx context.Context, key string) (io.ReadCloser, Info, error)
+	// Delete removes blob, missing blob is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Info describes stored blob
+type Info struct {
+	ContentType string
+	Size        int64
+}
+
+// NewStoreFromConfig creates store selected in config
+func NewStoreFromConfig(ctx context.Context, cfg config.BlobStoreConfig) (BlobStore, error) {
+	switch cfg.Type {
+	case "fs":
+		return NewFSStore(cfg.Path)
+	case "s3":
+		return NewS3Store(ctx, S3Options{
+			Endpoint:  cfg.Endpoint,
+			AccessKey: cfg.AccessKey,
+			SecretKey: cfg.SecretKey,
+			Bucket:    cfg.Bucket,
+			Region:    cfg.Region,
+			UseSSL:    cfg.UseSSL,
+		})
+	}
+
+	return nil, errors.New("unknown blob store: " + cfg.Type)
+}
+
+// checkKey rejects keys escaping store root, e.g. "../secret", or naming root itself
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key ||
+		key == "." || key == ".." || strings.HasPrefix(key, "../") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+
+	return nil
+}
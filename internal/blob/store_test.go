@@ -0,0 +1,33 @@
+package blob
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckKey(t *testing.T) {
+	valid := []string{"avatars/user/1/64.jpg", "a.png", "a/b..c/d"}
+	for _, key := range valid {
+		if err := checkKey(key); err != nil {
+			t.Errorf("checkKey(%q) error = %v, want nil", key, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"/etc/passwd",
+		"..",
+		"../secret",
+		"avatars/../../secret",
+		"avatars/./64.jpg",
+		"avatars//64.jpg",
+		"avatars/",
+		`avatars\..\secret`,
+		".",
+	}
+	for _, key := range invalid {
+		if err := checkKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("checkKey(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -19,6 +21,214 @@ type ServerConfig struct {
 	AuthServiceUrl string `json:"auth_service_url" env:"AUTH_SERVICE_URL"`
 
 	DatabaseURL string `json:"database_url" env:"DATABASE_URL"`
+	// DisableAutoMigrate skips applying pending migrations on server start
+	DisableAutoMigrate bool `json:"disable_auto_migrate" env:"DISABLE_AUTO_MIGRATE"`
+	// MigrationsPath overrides migrations embedded into the binary
+	MigrationsPath string `json:"migrations_path" env:"MIGRATIONS_PATH"`
+	// MigrationLockTimeout limits waiting for other instance to finish migrations
+	MigrationLockTimeout Duration `json:"migration_lock_timeout" env:"MIGRATION_LOCK_TIMEOUT"`
+
+	// Genders are allowed codes of gender catalog, empty allows all
+	Genders []string `json:"genders" env:"GENDERS" env-separator:","`
+	// GenderCatalog adds genders to built-in "M", "F", "N" and "U" or
+	// relabels them, codes are single latin capital letters
+	GenderCatalog []GenderConfig `json:"gender_catalog"`
+
+	Tracing   TracingConfig   `json:"tracing" env-prefix:"TRACING_"`
+	AccessLog AccessLogConfig `json:"access_log" env-prefix:"ACCESS_LOG_"`
+	RateLimit RateLimitConfig `json:"rate_limit" env-prefix:"RATE_LIMIT_"`
+	Timeouts  TimeoutConfig   `json:"timeouts" env-prefix:"TIMEOUT_"`
+	Outbox    OutboxConfig    `json:"outbox" env-prefix:"OUTBOX_"`
+	Consumer  ConsumerConfig  `json:"consumer" env-prefix:"CONSUMER_"`
+	Webhooks  WebhookConfig   `json:"webhooks" env-prefix:"WEBHOOK_"`
+	Stream    StreamConfig    `json:"stream" env-prefix:"STREAM_"`
+
+	Verification VerificationConfig `json:"verification" env-prefix:"VERIFICATION_"`
+	Avatars      AvatarConfig       `json:"avatars" env-prefix:"AVATAR_"`
+}
+
+// GenderConfig is gender catalog entry
+type GenderConfig struct {
+	Code string `json:"code"`
+	// Labels are display names by language, e.g. {"en": "Other"}
+	Labels map[string]string `json:"labels"`
+	// Aliases are accepted on input in addition to code
+	Aliases []string `json:"aliases"`
+}
+
+type OutboxConfig struct {
+	// RelayEnabled starts relay publishing account events
+	RelayEnabled bool `json:"relay_enabled" env:"RELAY_ENABLED"`
+	// Publisher is one of "stdout", "file" or "memory"
+	Publisher    string   `json:"publisher" env:"PUBLISHER"`
+	FilePath     string   `json:"file_path" env:"FILE_PATH"`
+	PollInterval Duration `json:"poll_interval" env:"POLL_INTERVAL"`
+	BatchSize    int      `json:"batch_size" env:"BATCH_SIZE"`
+	MinBackoff   Duration `json:"min_backoff" env:"MIN_BACKOFF"`
+	MaxBackoff   Duration `json:"max_backoff" env:"MAX_BACKOFF"`
+}
+
+type ConsumerConfig struct {
+	// Enabled starts consumer of auth service events
+	Enabled bool `json:"enabled" env:"ENABLED"`
+	// Subscriber is "file", reading NDJSON events from FilePath
+	Subscriber string `json:"subscriber" env:"SUBSCRIBER"`
+	FilePath   string `json:"file_path" env:"FILE_PATH"`
+	// PollInterval is how often file is checked for new events
+	PollInterval Duration `json:"poll_interval" env:"POLL_INTERVAL"`
+	MaxAttempts  int      `json:"max_attempts" env:"MAX_ATTEMPTS"`
+	RetryBackoff Duration `json:"retry_backoff" env:"RETRY_BACKOFF"`
+}
+
+type WebhookConfig struct {
+	// Enabled starts webhook dispatcher, events come from outbox relay
+	Enabled      bool     `json:"enabled" env:"ENABLED"`
+	PollInterval Duration `json:"poll_interval" env:"POLL_INTERVAL"`
+	BatchSize    int      `json:"batch_size" env:"BATCH_SIZE"`
+	// Timeout limits single delivery attempt
+	Timeout     Duration `json:"timeout" env:"TIMEOUT"`
+	MaxAttempts int      `json:"max_attempts" env:"MAX_ATTEMPTS"`
+	MinBackoff  Duration `json:"min_backoff" env:"MIN_BACKOFF"`
+	MaxBackoff  Duration `json:"max_backoff" env:"MAX_BACKOFF"`
+}
+
+// StreamConfig configures SSE stream of account events.
+// Every replica follows outbox table itself, relay is not required.
+type StreamConfig struct {
+	// LogSize is number of recent events kept for Last-Event-ID resume
+	LogSize int `json:"log_size" env:"LOG_SIZE"`
+	// PollInterval is how often outbox is checked for new events
+	PollInterval Duration `json:"poll_interval" env:"POLL_INTERVAL"`
+	// GapTimeout is how long events of transactions committed out of order are waited for
+	GapTimeout Duration `json:"gap_timeout" env:"GAP_TIMEOUT"`
+	// ClientBuffer is number of events queued per client before it is disconnected
+	ClientBuffer int      `json:"client_buffer" env:"CLIENT_BUFFER"`
+	Heartbeat    Duration `json:"heartbeat" env:"HEARTBEAT"`
+}
+
+// VerificationConfig configures codes confirming account email and phone
+type VerificationConfig struct {
+	// Sender is "log", writing messages to service log, or "file",
+	// appending them as NDJSON to FilePath
+	Sender   string `json:"sender" env:"SENDER"`
+	FilePath string `json:"file_path" env:"FILE_PATH"`
+	// Secret keys code hashes, pending codes become invalid when it changes
+	Secret     string   `json:"secret" env:"SECRET"`
+	CodeLength int      `json:"code_length" env:"CODE_LENGTH"`
+	CodeTTL    Duration `json:"code_ttl" env:"CODE_TTL"`
+	// ResendCooldown is minimal interval between codes sent to one contact
+	ResendCooldown Duration `json:"resend_cooldown" env:"RESEND_COOLDOWN"`
+	// MaxAttempts is number of wrong codes after which new code must be requested
+	MaxAttempts int `json:"max_attempts" env:"MAX_ATTEMPTS"`
+}
+
+// AvatarConfig configures upload of account pictures
+type AvatarConfig struct {
+	// MaxSize limits uploaded file in bytes
+	MaxSize int64 `json:"max_size" env:"MAX_SIZE"`
+	// MaxPixels limits decoded image width * height
+	MaxPixels int `json:"max_pixels" env:"MAX_PIXELS"`
+	// Sizes are sides of square thumbnails in pixels
+	Sizes []int `json:"sizes" env:"SIZES" env-separator:","`
+	// BaseURL prefixes blob keys in avatar URLs, e.g. "/api/v1/account/avatars"
+	// served from store by the service or public bucket URL
+	BaseURL string          `json:"base_url" env:"BASE_URL"`
+	Store   BlobStoreConfig `json:"store" env-prefix:"STORE_"`
+}
+
+type BlobStoreConfig struct {
+	// Type is "fs", keeping files under Path, or "s3"
+	Type string `json:"type" env:"TYPE"`
+	Path string `json:"path" env:"PATH"`
+	// Endpoint is host:port of S3-compatible storage, e.g. local MinIO
+	Endpoint  string `json:"endpoint" env:"ENDPOINT"`
+	AccessKey string `json:"access_key" env:"ACCESS_KEY"`
+	SecretKey string `json:"secret_key" env:"SECRET_KEY"`
+	Bucket    string `json:"bucket" env:"BUCKET"`
+	Region    string `json:"region" env:"REGION"`
+	UseSSL    bool   `json:"use_ssl" env:"USE_SSL"`
+}
+
+type TimeoutConfig struct {
+	// Server level timeouts
+	Read       Duration `json:"read" env:"READ"`
+	ReadHeader Duration `json:"read_header" env:"READ_HEADER"`
+	Write      Duration `json:"write" env:"WRITE"`
+	Idle       Duration `json:"idle" env:"IDLE"`
+
+	// Handler is default request deadline
+	Handler Duration `json:"handler" env:"HANDLER"`
+	// Routes overrides handler deadline by route name, e.g. "get-account"
+	Routes map[string]Duration `json:"routes"`
+}
+
+// Route returns request deadline for route name
+func (c TimeoutConfig) Route(route string) time.Duration {
+	if timeout, ok := c.Routes[route]; ok {
+		return time.Duration(timeout)
+	}
+
+	return time.Duration(c.Handler)
+}
+
+type TracingConfig struct {
+	// Exporter is one of "otlp", "stdout", "file" or empty to disable tracing
+	Exporter string `json:"exporter" env:"EXPORTER"`
+	// OtlpEndpoint is host:port of the OTLP/HTTP collector
+	OtlpEndpoint string `json:"otlp_endpoint" env:"OTLP_ENDPOINT"`
+	OtlpInsecure bool   `json:"otlp_insecure" env:"OTLP_INSECURE"`
+	// FilePath is used by the "file" exporter
+	FilePath    string  `json:"file_path" env:"FILE_PATH"`
+	ServiceName string  `json:"service_name" env:"SERVICE_NAME"`
+	SampleRatio float64 `json:"sample_ratio" env:"SAMPLE_RATIO"`
+}
+
+type AccessLogConfig struct {
+	Enabled bool `json:"enabled" env:"ENABLED"`
+	// SuccessSampleRate is a share of successful requests to log (0..1),
+	// requests finished with error status are always logged
+	SuccessSampleRate float64 `json:"success_sample_rate" env:"SUCCESS_SAMPLE_RATE"`
+	// LogHeaders enables logging of request headers
+	LogHeaders bool `json:"log_headers" env:"LOG_HEADERS"`
+	// Header and query parameter names whose values are replaced in log
+	RedactHeaders     []string `json:"redact_headers" env:"REDACT_HEADERS" env-separator:","`
+	RedactQueryParams []string `json:"redact_query_params" env:"REDACT_QUERY_PARAMS" env-separator:","`
+}
+
+type RateLimitConfig struct {
+	Enabled bool `json:"enabled" env:"ENABLED"`
+	// TrustProxyHeaders makes X-Forwarded-For and X-Real-IP used for client IP
+	TrustProxyHeaders bool   `json:"trust_proxy_headers" env:"TRUST_PROXY_HEADERS"`
+	APIKeyHeader      string `json:"api_key_header" env:"API_KEY_HEADER"`
+
+	Default RateLimitRule `json:"default" env-prefix:"DEFAULT_"`
+	// Routes overrides default rule by route name, e.g. "get-account"
+	Routes map[string]RateLimitRule `json:"routes"`
+	// Client limits callers by API key or IP before auth, default rule is used if empty
+	Client RateLimitRule `json:"client" env-prefix:"CLIENT_"`
+}
+
+type RateLimitRule struct {
+	RequestsPerSecond float64 `json:"requests_per_second" env:"REQUESTS_PER_SECOND"`
+	Burst             int     `json:"burst" env:"BURST"`
+}
+
+// Rule returns rate limit rule for route name
+func (c RateLimitConfig) Rule(route string) RateLimitRule {
+	if rule, ok := c.Routes[route]; ok {
+		return rule
+	}
+
+	return c.Default
+}
+
+// ClientRule returns rate limit rule for unauthenticated callers
+func (c RateLimitConfig) ClientRule() RateLimitRule {
+	if c.Client.RequestsPerSecond > 0 {
+		return c.Client
+	}
+
+	return c.Default
 }
 
 func NewServerConfig() *ServerConfig {
@@ -36,6 +246,8 @@ func NewServerConfig() *ServerConfig {
 		return nil
 	}
 
+	setDefaults(cfg)
+
 	// Validate config
 	if err := validateConfig(cfg); err != nil {
 		slog.Error(fmt.Errorf("failed to load config: %w, %w", fileErr, envErr).Error())
@@ -81,7 +293,7 @@ func loadFromEnv(cfg *ServerConfig) error {
 
 	// Check if any env var was actually set
 	emptyCfg := &ServerConfig{}
-	if *cfg == *emptyCfg {
+	if reflect.DeepEqual(cfg, emptyCfg) {
 		return errors.New("no env vars found")
 	}
 
@@ -89,6 +301,61 @@ func loadFromEnv(cfg *ServerConfig) error {
 	return nil
 }
 
+func setDefaults(cfg *ServerConfig) {
+	if cfg.MigrationLockTimeout == 0 {
+		cfg.MigrationLockTimeout = Duration(time.Minute)
+	}
+
+	// Zero server timeouts mean none, so slow clients could hang forever
+	if cfg.Timeouts.Read == 0 {
+		cfg.Timeouts.Read = Duration(10 * time.Second)
+	}
+	if cfg.Timeouts.ReadHeader == 0 {
+		cfg.Timeouts.ReadHeader = Duration(5 * time.Second)
+	}
+	if cfg.Timeouts.Write == 0 {
+		cfg.Timeouts.Write = Duration(15 * time.Second)
+	}
+	if cfg.Timeouts.Idle == 0 {
+		cfg.Timeouts.Idle = Duration(60 * time.Second)
+	}
+	if cfg.Timeouts.Handler == 0 {
+		cfg.Timeouts.Handler = Duration(5 * time.Second)
+	}
+
+	if cfg.Verification.Sender == "" {
+		cfg.Verification.Sender = "log"
+	}
+	if cfg.Verification.CodeLength == 0 {
+		cfg.Verification.CodeLength = 6
+	}
+	if cfg.Verification.CodeTTL == 0 {
+		cfg.Verification.CodeTTL = Duration(10 * time.Minute)
+	}
+	if cfg.Verification.ResendCooldown == 0 {
+		cfg.Verification.ResendCooldown = Duration(time.Minute)
+	}
+	if cfg.Verification.MaxAttempts == 0 {
+		cfg.Verification.MaxAttempts = 5
+	}
+
+	if cfg.Avatars.MaxSize == 0 {
+		cfg.Avatars.MaxSize = 5 << 20
+	}
+	if cfg.Avatars.MaxPixels == 0 {
+		cfg.Avatars.MaxPixels = 40_000_000
+	}
+	if len(cfg.Avatars.Sizes) == 0 {
+		cfg.Avatars.Sizes = []int{64, 128, 256, 512}
+	}
+	if cfg.Avatars.BaseURL == "" {
+		cfg.Avatars.BaseURL = "/api/v1/account/avatars"
+	}
+	if cfg.Avatars.Store.Type == "" {
+		cfg.Avatars.Store.Type = "fs"
+	}
+}
+
 func validateConfig(cfg *ServerConfig) error {
 	var missing []string
 
@@ -102,6 +369,125 @@ func validateConfig(cfg *ServerConfig) error {
 		missing = append(missing, "database_url")
 	}
 
+	switch cfg.Tracing.Exporter {
+	case "", "stdout":
+	case "otlp":
+		if cfg.Tracing.OtlpEndpoint == "" {
+			missing = append(missing, "tracing.otlp_endpoint")
+		}
+	case "file":
+		if cfg.Tracing.FilePath == "" {
+			missing = append(missing, "tracing.file_path")
+		}
+	default:
+		return fmt.Errorf("unknown tracing exporter: %s", cfg.Tracing.Exporter)
+	}
+
+	if rate := cfg.AccessLog.SuccessSampleRate; rate < 0 || rate > 1 {
+		return fmt.Errorf("access_log.success_sample_rate must be in range 0..1, got %v", rate)
+	}
+
+	if cfg.RateLimit.Enabled {
+		if err := validateRateLimitRule("default", cfg.RateLimit.Default); err != nil {
+			return err
+		}
+		if cfg.RateLimit.Client != (RateLimitRule{}) {
+			if err := validateRateLimitRule("client", cfg.RateLimit.Client); err != nil {
+				return err
+			}
+		}
+		for route, rule := range cfg.RateLimit.Routes {
+			if err := validateRateLimitRule(route, rule); err != nil {
+				return err
+			}
+		}
+	}
+
+	for route, timeout := range cfg.Timeouts.Routes {
+		if timeout <= 0 {
+			return fmt.Errorf("timeouts.routes.%s must be positive", route)
+		}
+		if timeout >= cfg.Timeouts.Write {
+			return fmt.Errorf("timeouts.routes.%s must be less than timeouts.write", route)
+		}
+	}
+	if cfg.Timeouts.Handler >= cfg.Timeouts.Write {
+		return errors.New("timeouts.handler must be less than timeouts.write")
+	}
+
+	if cfg.Outbox.RelayEnabled {
+		switch cfg.Outbox.Publisher {
+		case "stdout", "memory":
+		case "file":
+			if cfg.Outbox.FilePath == "" {
+				missing = append(missing, "outbox.file_path")
+			}
+		default:
+			return fmt.Errorf("unknown outbox publisher: %s", cfg.Outbox.Publisher)
+		}
+	}
+
+	if cfg.Consumer.Enabled {
+		switch cfg.Consumer.Subscriber {
+		case "file":
+			if cfg.Consumer.FilePath == "" {
+				missing = append(missing, "consumer.file_path")
+			}
+		default:
+			return fmt.Errorf("unknown consumer subscriber: %s", cfg.Consumer.Subscriber)
+		}
+	}
+
+	switch cfg.Verification.Sender {
+	case "log":
+	case "file":
+		if cfg.Verification.FilePath == "" {
+			missing = append(missing, "verification.file_path")
+		}
+	default:
+		return fmt.Errorf("unknown verification sender: %s", cfg.Verification.Sender)
+	}
+	if cfg.Verification.Secret == "" {
+		missing = append(missing, "verification.secret")
+	}
+	if length := cfg.Verification.CodeLength; length < 4 || length > 10 {
+		return fmt.Errorf("verification.code_length must be in range 4..10, got %d", length)
+	}
+	if cfg.Verification.CodeTTL <= cfg.Verification.ResendCooldown {
+		return errors.New("verification.code_ttl must be greater than verification.resend_cooldown")
+	}
+	if cfg.Verification.MaxAttempts < 1 {
+		return errors.New("verification.max_attempts must be positive")
+	}
+
+	if cfg.Avatars.MaxSize <= 0 || cfg.Avatars.MaxPixels <= 0 {
+		return errors.New("avatars.max_size and avatars.max_pixels must be positive")
+	}
+	for _, size := range cfg.Avatars.Sizes {
+		if size < 16 || size > 2048 {
+			return fmt.Errorf("avatars.sizes must be in range 16..2048, got %d", size)
+		}
+	}
+	switch cfg.Avatars.Store.Type {
+	case "fs":
+		if cfg.Avatars.Store.Path == "" {
+			missing = append(missing, "avatars.store.path")
+		}
+	case "s3":
+		if cfg.Avatars.Store.Endpoint == "" {
+			missing = append(missing, "avatars.store.endpoint")
+		}
+		if cfg.Avatars.Store.Bucket == "" {
+			missing = append(missing, "avatars.store.bucket")
+		}
+	default:
+		return fmt.Errorf("unknown avatars store: %s", cfg.Avatars.Store.Type)
+	}
+
+	if cfg.Webhooks.Enabled && !cfg.Outbox.RelayEnabled {
+		return errors.New("webhooks require outbox.relay_enabled")
+	}
+
 	if len(missing) > 0 {
 		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
 	}
@@ -109,6 +495,14 @@ func validateConfig(cfg *ServerConfig) error {
 	return nil
 }
 
+func validateRateLimitRule(name string, rule RateLimitRule) error {
+	if rule.RequestsPerSecond <= 0 || rule.Burst < 1 {
+		return fmt.Errorf("rate_limit rule %q must have positive requests_per_second and burst", name)
+	}
+
+	return nil
+}
+
 func findModuleRoot(dir string) (string, error) {
 	for i := 0; i < 10; i++ {
 		if dir == "" || dir == "/" {
@@ -0,0 +1,211 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/events"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// Placeholder values for profile fields unknown at registration,
+// user replaces them later through account update. Birthdate stays unknown.
+const (
+	placeholderName   = "-"
+	placeholderGender = domain.GenderUndisclosed
+)
+
+type AccountCreator interface {
+	Provision(ctx context.Context, account domain.Account) error
+}
+
+// ProcessedStore deduplicates messages by event id
+type ProcessedStore interface {
+	IsProcessed(ctx context.Context, eventId string) (bool, error)
+	MarkProcessed(ctx context.Context, eventId, eventType string) error
+}
+
+// DeadLetterStore keeps messages which could not be processed
+type DeadLetterStore interface {
+	SaveDeadLetter(ctx context.Context, letter events.DeadLetter) error
+}
+
+type Options struct {
+	// MaxAttempts is number of tries for transient errors before dead-lettering
+	MaxAttempts  int
+	RetryBackoff time.Duration
+}
+
+// Consumer handles events of other services
+type Consumer struct {
+	subscriber  Subscriber
+	accounts    AccountCreator
+	processed   ProcessedStore
+	deadLetters DeadLetterStore
+	logger      *slog.Logger
+	opts        Options
+}
+
+func NewConsumer(s Subscriber, accounts AccountCreator, processed ProcessedStore,
+	deadLetters DeadLetterStore, l *slog.Logger, opts Options) *Consumer {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = time.Second
+	}
+
+	return &Consumer{
+		subscriber:  s,
+		accounts:    accounts,
+		processed:   processed,
+		deadLetters: deadLetters,
+		logger:      l,
+		opts:        opts,
+	}
+}
+
+// Run consumes messages until ctx is canceled or subscription ends
+func (c *Consumer) Run(ctx context.Context) error {
+	c.logger.Info("consumer started")
+	defer c.logger.Info("consumer stopped")
+
+	return c.subscriber.Subscribe(ctx, c.Handle)
+}
+
+// permanentError marks message which will never succeed on retry
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+func (e permanentError) Unwrap() error { return e.err }
+
+// Handle processes single message.
+// Error is returned only when message state could not be stored.
+func (c *Consumer) Handle(ctx context.Context, msg events.InboundMessage) error {
+	logger := c.logger.With("event_id", msg.ID, "event_type", msg.Type)
+
+	if msg.ID != "" {
+		processed, err := c.processed.IsProcessed(ctx, msg.ID)
+		if err != nil {
+			return err
+		}
+		if processed {
+			logger.Debug("skipping duplicate event")
+			return nil
+		}
+	}
+
+	if msg.ID == "" || msg.Type == "" {
+		return c.deadLetter(ctx, logger, msg, errors.New("malformed message: id and type are required"), 1)
+	}
+
+	var handle func(context.Context, events.InboundMessage) error
+	switch msg.Type {
+	case events.UserRegistered:
+		handle = c.provisionAccount
+	default:
+		// Other event types may share the same topic
+		logger.Debug("skipping unknown event type")
+		return nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := handle(ctx, msg)
+		if err == nil {
+			break
+		}
+
+		var permanent permanentError
+		if errors.As(err, &permanent) || attempt >= c.opts.MaxAttempts {
+			return c.deadLetter(ctx, logger, msg, err, attempt)
+		}
+
+		logger.Warn("failed to handle event, retrying", "attempt", attempt, slogerr.Error(err))
+
+		select {
+		case <-ctx.Done():
+			// Message is not marked processed and will be redelivered
+			return ctx.Err()
+		case <-time.After(c.opts.RetryBackoff * time.Duration(attempt)):
+		}
+	}
+
+	return c.processed.MarkProcessed(ctx, msg.ID, msg.Type)
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, logger *slog.Logger,
+	msg events.InboundMessage, cause error, attempts int) error {
+	logger.Error("moving event to dead letters", "attempts", attempts, slogerr.Error(cause))
+
+	err := c.deadLetters.SaveDeadLetter(ctx, events.DeadLetter{
+		EventID:   msg.ID,
+		EventType: msg.Type,
+		Payload:   string(msg.Payload),
+		Error:     cause.Error(),
+		Attempts:  attempts,
+		FailedAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Malformed messages have no id to deduplicate by
+	if msg.ID == "" {
+		return nil
+	}
+
+	return c.processed.MarkProcessed(ctx, msg.ID, msg.Type)
+}
+
+// provisionAccount creates placeholder account for registered user
+func (c *Consumer) provisionAccount(ctx context.Context, msg events.InboundMessage) error {
+	var payload events.UserRegisteredPayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return permanentError{fmt.Errorf("failed to decode payload: %w", err)}
+	}
+	if payload.UserId == uuid.Nil {
+		return permanentError{errors.New("user_id is required")}
+	}
+
+	account, err := domain.NewAccount(payload.UserId, orPlaceholder(payload.Firstname),
+		orPlaceholder(payload.Surname), payload.Patronymic, placeholderGender,
+		time.Time{}, domain.Profile{}, domain.Contacts{})
+	if err != nil {
+		return permanentError{err}
+	}
+
+	err = c.accounts.Provision(usecase.WithActor(ctx, "consumer:"+msg.Type), account)
+	if err != nil {
+		// Account may be created by client or previous delivery
+		if errors.Is(err, domain.ErrAccountExists) {
+			return nil
+		}
+
+		// Placeholder gender may be excluded from catalog
+		if errors.Is(err, domain.ErrInvalidGender) {
+			return permanentError{err}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func orPlaceholder(s string) string {
+	if s == "" {
+		return placeholderName
+	}
+
+	return s
+}
@@ -0,0 +1,272 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/events"
+	"github.com/google/uuid"
+)
+
+// fakeAccounts records provisioned accounts, errs are returned by calls in order
+type fakeAccounts struct {
+	mu       sync.Mutex
+	accounts []domain.Account
+	errs     []error
+}
+
+func (a *fakeAccounts) Provision(_ context.Context, account domain.Account) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.accounts = append(a.accounts, account)
+
+	if len(a.errs) == 0 {
+		return nil
+	}
+
+	err := a.errs[0]
+	a.errs = a.errs[1:]
+	return err
+}
+
+func (a *fakeAccounts) calls() []domain.Account {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]domain.Account(nil), a.accounts...)
+}
+
+func newTestConsumer(s Subscriber, accounts AccountCreator, store *MemoryStore, maxAttempts int) *Consumer {
+	return NewConsumer(s, accounts, store, store, slog.New(slog.NewTextHandler(io.Discard, nil)), Options{
+		MaxAttempts:  maxAttempts,
+		RetryBackoff: time.Millisecond,
+	})
+}
+
+func registered(t *testing.T, id string, payload events.UserRegisteredPayload) events.InboundMessage {
+	t.Helper()
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+
+	return events.InboundMessage{ID: id, Type: events.UserRegistered, Payload: data}
+}
+
+// consume runs consumer over messages until they are all handled
+func consume(t *testing.T, c *Consumer, messages chan events.InboundMessage) {
+	t.Helper()
+
+	close(messages)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
+func TestConsumerProvisionsAccount(t *testing.T) {
+	userId := uuid.New()
+	accounts := &fakeAccounts{}
+	store := NewMemoryStore()
+
+	messages := make(chan events.InboundMessage, 1)
+	messages <- registered(t, "event-1", events.UserRegisteredPayload{UserId: userId, Firstname: "Иван"})
+	consume(t, newTestConsumer(NewChannelSubscriber(messages), accounts, store, 3), messages)
+
+	calls := accounts.calls()
+	if len(calls) != 1 {
+		t.Fatalf("Provision() called %d times, want 1", len(calls))
+	}
+
+	account := calls[0]
+	if account.UserId != userId || account.Firstname != "Иван" {
+		t.Errorf("account = %+v, want user %s named Иван", account, userId)
+	}
+	if account.Surname != placeholderName || account.Gender != placeholderGender {
+		t.Errorf("surname = %q, gender = %q, want placeholders", account.Surname, account.Gender)
+	}
+	if account.HasBirthdate() {
+		t.Errorf("birthdate = %s, want unknown", account.Birthdate)
+	}
+
+	if processed, _ := store.IsProcessed(context.Background(), "event-1"); !processed {
+		t.Error("event is not marked processed")
+	}
+}
+
+func TestConsumerSkipsDuplicateEvent(t *testing.T) {
+	accounts := &fakeAccounts{}
+	store := NewMemoryStore()
+	msg := registered(t, "event-1", events.UserRegisteredPayload{UserId: uuid.New()})
+
+	messages := make(chan events.InboundMessage, 2)
+	messages <- msg
+	messages <- msg
+	consume(t, newTestConsumer(NewChannelSubscriber(messages), accounts, store, 3), messages)
+
+	// Redelivery after restart is skipped too
+	messages = make(chan events.InboundMessage, 1)
+	messages <- msg
+	consume(t, newTestConsumer(NewChannelSubscriber(messages), accounts, store, 3), messages)
+
+	if calls := accounts.calls(); len(calls) != 1 {
+		t.Fatalf("Provision() called %d times, want 1", len(calls))
+	}
+}
+
+func TestConsumerSkipsExistingAccount(t *testing.T) {
+	accounts := &fakeAccounts{errs: []error{domain.ErrAccountExists}}
+	store := NewMemoryStore()
+
+	messages := make(chan events.InboundMessage, 1)
+	messages <- registered(t, "event-1", events.UserRegisteredPayload{UserId: uuid.New()})
+	consume(t, newTestConsumer(NewChannelSubscriber(messages), accounts, store, 3), messages)
+
+	if calls := accounts.calls(); len(calls) != 1 {
+		t.Fatalf("Provision() called %d times, want 1 without retries", len(calls))
+	}
+	if letters := store.DeadLetters(); len(letters) != 0 {
+		t.Fatalf("dead letters = %+v, want none", letters)
+	}
+	if processed, _ := store.IsProcessed(context.Background(), "event-1"); !processed {
+		t.Error("event is not marked processed")
+	}
+}
+
+func TestConsumerDeadLettersAfterMaxAttempts(t *testing.T) {
+	const maxAttempts = 3
+
+	transient := errors.New("database is unavailable")
+	accounts := &fakeAccounts{errs: []error{transient, transient, transient, transient}}
+	store := NewMemoryStore()
+
+	messages := make(chan events.InboundMessage, 1)
+	messages <- registered(t, "event-1", events.UserRegisteredPayload{UserId: uuid.New()})
+	consume(t, newTestConsumer(NewChannelSubscriber(messages), accounts, store, maxAttempts), messages)
+
+	if calls := accounts.calls(); len(calls) != maxAttempts {
+		t.Fatalf("Provision() called %d times, want %d", len(calls), maxAttempts)
+	}
+
+	letters := store.DeadLetters()
+	if len(letters) != 1 {
+		t.Fatalf("dead letters = %d, want 1", len(letters))
+	}
+	if letters[0].EventID != "event-1" || letters[0].Attempts != maxAttempts || letters[0].Error != transient.Error() {
+		t.Errorf("dead letter = %+v, want event-1 after %d attempts", letters[0], maxAttempts)
+	}
+	if processed, _ := store.IsProcessed(context.Background(), "event-1"); !processed {
+		t.Error("dead-lettered event is not marked processed")
+	}
+}
+
+func TestConsumerRetriesTransientError(t *testing.T) {
+	accounts := &fakeAccounts{errs: []error{errors.New("database is unavailable")}}
+	store := NewMemoryStore()
+
+	messages := make(chan events.InboundMessage, 1)
+	messages <- registered(t, "event-1", events.UserRegisteredPayload{UserId: uuid.New()})
+	consume(t, newTestConsumer(NewChannelSubscriber(messages), accounts, store, 3), messages)
+
+	if calls := accounts.calls(); len(calls) != 2 {
+		t.Fatalf("Provision() called %d times, want 2", len(calls))
+	}
+	if letters := store.DeadLetters(); len(letters) != 0 {
+		t.Fatalf("dead letters = %+v, want none", letters)
+	}
+}
+
+func TestConsumerDeadLettersPermanentErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  events.InboundMessage
+	}{
+		{name: "malformed payload", msg: events.InboundMessage{
+			ID: "event-1", Type: events.UserRegistered, Payload: json.RawMessage(`{"user_id":`)}},
+		{name: "missing user id", msg: events.InboundMessage{
+			ID: "event-1", Type: events.UserRegistered, Payload: json.RawMessage(`{}`)}},
+		{name: "missing type", msg: events.InboundMessage{ID: "event-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := &fakeAccounts{}
+			store := NewMemoryStore()
+
+			messages := make(chan events.InboundMessage, 1)
+			messages <- tt.msg
+			consume(t, newTestConsumer(NewChannelSubscriber(messages), accounts, store, 3), messages)
+
+			if calls := accounts.calls(); len(calls) != 0 {
+				t.Fatalf("Provision() called %d times, want 0", len(calls))
+			}
+
+			letters := store.DeadLetters()
+			if len(letters) != 1 || letters[0].Attempts != 1 {
+				t.Fatalf("dead letters = %+v, want one after single attempt", letters)
+			}
+		})
+	}
+}
+
+func TestConsumerSkipsUnknownEventType(t *testing.T) {
+	accounts := &fakeAccounts{}
+	store := NewMemoryStore()
+
+	messages := make(chan events.InboundMessage, 1)
+	messages <- events.InboundMessage{ID: "event-1", Type: "user.logged_in", Payload: json.RawMessage(`{}`)}
+	consume(t, newTestConsumer(NewChannelSubscriber(messages), accounts, store, 3), messages)
+
+	if calls := accounts.calls(); len(calls) != 0 {
+		t.Fatalf("Provision() called %d times, want 0", len(calls))
+	}
+	if letters := store.DeadLetters(); len(letters) != 0 {
+		t.Fatalf("dead letters = %+v, want none", letters)
+	}
+}
+
+func TestFileSubscriber(t *testing.T) {
+	userId := uuid.New()
+	msg := registered(t, "event-1", events.UserRegisteredPayload{UserId: userId})
+
+	line, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal message: %v", err)
+	}
+
+	// Broken line, blank line, the same event twice, last line without newline
+	data := "not json\n\n" + string(line) + "\n" + string(line)
+
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	if err = os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("write events file: %v", err)
+	}
+
+	accounts := &fakeAccounts{}
+	store := NewMemoryStore()
+	c := newTestConsumer(NewFileSubscriber(path, 0), accounts, store, 3)
+
+	if err = c.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	calls := accounts.calls()
+	if len(calls) != 1 || calls[0].UserId != userId {
+		t.Fatalf("provisioned = %+v, want one account of %s", calls, userId)
+	}
+
+	letters := store.DeadLetters()
+	if len(letters) != 1 || letters[0].EventID != path+":1" || letters[0].Payload != "not json" {
+		t.Fatalf("dead letters = %+v, want broken first line", letters)
+	}
+}
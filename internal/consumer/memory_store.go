@@ -0,0 +1,53 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+)
+
+// MemoryStore is in-memory ProcessedStore and DeadLetterStore, for local runs and tests
+type MemoryStore struct {
+	mu          sync.Mutex
+	processed   map[string]struct{}
+	deadLetters []events.DeadLetter
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		processed: make(map[string]struct{}),
+	}
+}
+
+func (s *MemoryStore) IsProcessed(_ context.Context, eventId string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.processed[eventId]
+	return ok, nil
+}
+
+func (s *MemoryStore) MarkProcessed(_ context.Context, eventId, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.processed[eventId] = struct{}{}
+	return nil
+}
+
+func (s *MemoryStore) SaveDeadLetter(_ context.Context, letter events.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadLetters = append(s.deadLetters, letter)
+	return nil
+}
+
+// DeadLetters returns copy of stored dead letters
+func (s *MemoryStore) DeadLetters() []events.DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]events.DeadLetter(nil), s.deadLetters...)
+}
@@ -0,0 +1,132 @@
+package consumer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+)
+
+// Handler processes single inbound message
+type Handler func(ctx context.Context, msg events.InboundMessage) error
+
+// Subscriber delivers inbound messages to handler until ctx is canceled.
+// Messages are handled one by one, handler error stops subscription.
+type Subscriber interface {
+	Subscribe(ctx context.Context, handler Handler) error
+}
+
+// ChannelSubscriber reads messages from channel, for local runs and tests
+type ChannelSubscriber struct {
+	messages <-chan events.InboundMessage
+}
+
+func NewChannelSubscriber(messages <-chan events.InboundMessage) *ChannelSubscriber {
+	return &ChannelSubscriber{messages: messages}
+}
+
+// Subscribe returns when channel is closed or ctx is canceled
+func (s *ChannelSubscriber) Subscribe(ctx context.Context, handler Handler) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-s.messages:
+			if !ok {
+				return nil
+			}
+			if err := handler(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// FileSubscriber reads NDJSON messages from file.
+// With positive poll interval it waits for appended lines like tail -f,
+// otherwise it stops at end of file.
+type FileSubscriber struct {
+	path         string
+	pollInterval time.Duration
+}
+
+func NewFileSubscriber(path string, pollInterval time.Duration) *FileSubscriber {
+	return &FileSubscriber{
+		path:         path,
+		pollInterval: pollInterval,
+	}
+}
+
+func (s *FileSubscriber) Subscribe(ctx context.Context, handler Handler) error {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to open events file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	var (
+		partial []byte
+		line    int
+	)
+	for {
+		chunk, err := reader.ReadBytes('\n')
+		partial = append(partial, chunk...)
+
+		if errors.Is(err, io.EOF) {
+			if s.pollInterval <= 0 {
+				// Last line may have no trailing newline
+				if len(bytes.TrimSpace(partial)) == 0 {
+					return nil
+				}
+			} else {
+				// Wait until line is written completely
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(s.pollInterval):
+				}
+				continue
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to read events file: %w", err)
+		}
+
+		raw := bytes.TrimSpace(partial)
+		partial = nil
+		line++
+
+		if len(raw) == 0 {
+			continue
+		}
+
+		if err := handler(ctx, s.decode(raw, line)); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// decode parses message, malformed line is passed to handler
+// without type so it ends up in dead letters
+func (s *FileSubscriber) decode(raw []byte, line int) events.InboundMessage {
+	var msg events.InboundMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return events.InboundMessage{
+			ID:      fmt.Sprintf("%s:%d", s.path, line),
+			Payload: raw,
+		}
+	}
+
+	return msg
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/config"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/go-chi/chi"
+	chimiddleware "github.com/go-chi/chi/middleware"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	defaultRedactHeaders     = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+	defaultRedactQueryParams = []string{"token", "access_token", "api_key", "password"}
+)
+
+type accessLogEntryKey struct{}
+
+// accessLogEntry is filled by inner middlewares, e.g. with user id after auth
+type accessLogEntry struct {
+	userId string
+}
+
+type accessLogger struct {
+	logger            *slog.Logger
+	successSampleRate float64
+	logHeaders        bool
+	redactHeaders     map[string]struct{}
+	redactQueryParams map[string]struct{}
+}
+
+// AccessLog logs every request finished with error status and
+// sampled share of successful ones
+func AccessLog(cfg config.AccessLogConfig, logger *slog.Logger) func(http.Handler) http.Handler {
+	if !cfg.Enabled {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	redactHeaders := cfg.RedactHeaders
+	if len(redactHeaders) == 0 {
+		redactHeaders = defaultRedactHeaders
+	}
+
+	redactQueryParams := cfg.RedactQueryParams
+	if len(redactQueryParams) == 0 {
+		redactQueryParams = defaultRedactQueryParams
+	}
+
+	l := &accessLogger{
+		logger:            logger,
+		successSampleRate: cfg.SuccessSampleRate,
+		logHeaders:        cfg.LogHeaders,
+		redactHeaders:     toSet(redactHeaders, http.CanonicalHeaderKey),
+		redactQueryParams: toSet(redactQueryParams, strings.ToLower),
+	}
+
+	return l.handler
+}
+
+func (l *accessLogger) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		entry := &accessLogEntry{}
+		ctx := context.WithValue(r.Context(), accessLogEntryKey{}, entry)
+
+		ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		if status < http.StatusBadRequest && !l.sampled() {
+			return
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("route", routePattern(r)),
+			slog.String("query", l.redactQuery(r.URL.Query())),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.String("remote_addr", r.RemoteAddr),
+		}
+		if entry.userId != "" {
+			attrs = append(attrs, slog.String("user_id", entry.userId))
+		}
+		if l.logHeaders {
+			attrs = append(attrs, slog.Any("headers", l.redactHeaderValues(r.Header)))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		slogerr.FromContext(r.Context(), l.logger).LogAttrs(r.Context(), level, "http request", attrs...)
+	})
+}
+
+func (l *accessLogger) sampled() bool {
+	if l.successSampleRate >= 1 {
+		return true
+	}
+
+	return rand.Float64() < l.successSampleRate
+}
+
+func (l *accessLogger) redactQuery(query url.Values) string {
+	for key := range query {
+		if _, ok := l.redactQueryParams[strings.ToLower(key)]; ok {
+			query[key] = []string{redacted}
+		}
+	}
+
+	return query.Encode()
+}
+
+func (l *accessLogger) redactHeaderValues(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key, values := range header {
+		if _, ok := l.redactHeaders[http.CanonicalHeaderKey(key)]; ok {
+			headers[key] = redacted
+			continue
+		}
+		headers[key] = strings.Join(values, ", ")
+	}
+
+	return headers
+}
+
+// setAccessLogUserID passes user id to access log middleware if it is registered
+func setAccessLogUserID(ctx context.Context, userId string) {
+	if entry, ok := ctx.Value(accessLogEntryKey{}).(*accessLogEntry); ok {
+		entry.userId = userId
+	}
+}
+
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+
+	return r.URL.Path
+}
+
+func toSet(values []string, normalize func(string) string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[normalize(strings.TrimSpace(v))] = struct{}{}
+	}
+
+	return set
+}
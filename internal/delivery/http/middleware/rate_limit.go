@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/config"
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+)
+
+const defaultAPIKeyHeader = "X-Api-Key"
+
+// RateLimit limits requests to a single route by user id, API key or client IP.
+// Must be registered after auth middleware to key by user id,
+// use ClientRateLimit before auth to protect it from unauthenticated callers.
+func RateLimit(cfg config.RateLimitConfig, route string, limiter ratelimit.Limiter,
+	logger *slog.Logger) func(http.Handler) http.Handler {
+	if !cfg.Enabled {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	apiKeyHeader := apiKeyHeader(cfg)
+
+	return limit(cfg.Rule(route), limiter, logger, func(r *http.Request) string {
+		return route + ":" + clientKey(r, apiKeyHeader, cfg.TrustProxyHeaders)
+	})
+}
+
+// ClientRateLimit limits requests to authenticated routes by API key or client IP.
+// Bucket is shared by all routes, so it must be registered before auth middleware
+// and bounds requests reaching auth service regardless of credentials.
+func ClientRateLimit(cfg config.RateLimitConfig, limiter ratelimit.Limiter,
+	logger *slog.Logger) func(http.Handler) http.Handler {
+	if !cfg.Enabled {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	apiKeyHeader := apiKeyHeader(cfg)
+
+	return limit(cfg.ClientRule(), limiter, logger, func(r *http.Request) string {
+		return "auth:" + callerKey(r, apiKeyHeader, cfg.TrustProxyHeaders)
+	})
+}
+
+func limit(rule config.RateLimitRule, limiter ratelimit.Limiter, logger *slog.Logger,
+	key func(r *http.Request) string) func(http.Handler) http.Handler {
+	limit := ratelimit.Limit{
+		RequestsPerSecond: rule.RequestsPerSecond,
+		Burst:             rule.Burst,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := limiter.Allow(r.Context(), key(r), limit)
+			if err != nil {
+				// Fail open, limiter store outage must not take service down
+				slogerr.FromContext(r.Context(), logger).Error("rate limiter failed", slogerr.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			h.Set("RateLimit-Reset", ceilSeconds(result.ResetAfter))
+
+			if !result.Allowed {
+				h.Set("Retry-After", ceilSeconds(result.RetryAfter))
+				response.JSON(w, http.StatusTooManyRequests, "too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func apiKeyHeader(cfg config.RateLimitConfig) string {
+	if cfg.APIKeyHeader == "" {
+		return defaultAPIKeyHeader
+	}
+
+	return cfg.APIKeyHeader
+}
+
+// clientKey picks the most specific caller identity available
+func clientKey(r *http.Request, apiKeyHeader string, trustProxy bool) string {
+	if userId, ok := r.Context().Value("user_id").(string); ok && userId != "" {
+		return "user:" + userId
+	}
+
+	return callerKey(r, apiKeyHeader, trustProxy)
+}
+
+// callerKey identifies caller by API key or IP, user id is ignored
+func callerKey(r *http.Request, apiKeyHeader string, trustProxy bool) string {
+	if apiKey := r.Header.Get(apiKeyHeader); apiKey != "" {
+		// Do not keep raw secrets in limiter store
+		sum := sha256.Sum256([]byte(apiKey))
+		return "apikey:" + hex.EncodeToString(sum[:8])
+	}
+
+	return "ip:" + clientIP(r, trustProxy)
+}
+
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			ip, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(ip)
+		}
+		if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+			return strings.TrimSpace(realIP)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
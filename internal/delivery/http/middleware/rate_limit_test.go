@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+)
+
+func TestClientRateLimitSetsRetryAfter(t *testing.T) {
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Default: config.RateLimitRule{RequestsPerSecond: 10, Burst: 10},
+		Client:  config.RateLimitRule{RequestsPerSecond: 0.5, Burst: 1},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	limit := ClientRateLimit(cfg, ratelimit.NewMemoryLimiter(0), logger)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	// Bucket is shared by routes behind auth
+	routes := []http.Handler{limit(ok), limit(ok)}
+
+	request := func(h http.Handler, apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		if apiKey != "" {
+			r.Header.Set("X-Api-Key", apiKey)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := request(routes[0], ""); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "1" {
+		t.Fatalf("first request = %d, limit %q, want 200 with limit 1", w.Code, w.Header().Get("RateLimit-Limit"))
+	}
+
+	w := request(routes[1], "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("Retry-After = %q, want 2", got)
+	}
+
+	// API key is limited separately from IP
+	if w = request(routes[1], "secret"); w.Code != http.StatusOK {
+		t.Fatalf("request with API key = %d, want 200", w.Code)
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	h := RateLimit(config.RateLimitConfig{}, "get-account", ratelimit.NewMemoryLimiter(0), logger)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for range 3 {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("request = %d, headers %v, want unlimited", w.Code, w.Header())
+		}
+	}
+}
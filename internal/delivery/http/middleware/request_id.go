@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+// RequestID accepts or generates X-Request-ID, returns it in response
+// and stores logger with request_id attached in request context
+func RequestID(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !isValidRequestID(requestID) {
+				requestID = uuid.NewString()
+			}
+
+			// Set before handler writes anything so error bodies can pick it up
+			w.Header().Set(RequestIDHeader, requestID)
+
+			ctx := context.WithValue(r.Context(), requestIDKey{}, requestID)
+			ctx = slogerr.WithLogger(ctx, logger.With("request_id", requestID))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserLogger attaches user_id from auth middleware to request logger.
+// Must be registered after auth middleware.
+func UserLogger(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userId, ok := r.Context().Value("user_id").(string)
+			if !ok || userId == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			setAccessLogUserID(r.Context(), userId)
+
+			log := slogerr.FromContext(r.Context(), logger).With("user_id", userId)
+			ctx := slogerr.WithLogger(r.Context(), log)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetRequestID returns request id stored by RequestID middleware
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	// Only printable ASCII to keep logs and headers safe
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+)
+
+// RequireRole allows only users with one of given roles.
+// Must be registered after auth middleware.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value("user_role").(string)
+			if !slices.Contains(roles, role) {
+				response.JSON(w, http.StatusForbidden, "forbidden")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+	chimiddleware "github.com/go-chi/chi/middleware"
+)
+
+// Timeout sets request deadline. If handler returns without writing
+// a response after the deadline, 504 is written instead.
+func Timeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if ww.Status() == 0 && ww.BytesWritten() == 0 {
+				if status, msg, ok := DeadlineStatus(ctx); ok {
+					response.JSON(w, status, msg)
+				}
+			}
+		})
+	}
+}
+
+// DeadlineStatus maps expired request context to response status
+func DeadlineStatus(ctx context.Context) (int, string, bool) {
+	switch err := ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, "request deadline exceeded", true
+	case errors.Is(err, context.Canceled):
+		return http.StatusServiceUnavailable, "request canceled", true
+	}
+
+	return 0, "", false
+}
@@ -3,7 +3,6 @@ package router
 import (
 	"context"
 	"errors"
-	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -11,9 +10,13 @@ import (
 	"time"
 
 	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/delivery/http/middleware"
+	"github.com/WebChads/AccountService/internal/models/domain"
 	"github.com/WebChads/AccountService/internal/models/dtos"
 	response "github.com/WebChads/AccountService/internal/pkg/api"
 	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
 	"github.com/WebChads/AccountService/pkg/middleware/auth"
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
@@ -22,8 +25,11 @@ import (
 )
 
 type AccountUsecase interface {
-	Create(ctx context.Context, dto dtos.CreateAccountRequest) error
-	Get(ctx context.Context, userId string) (*dtos.GetAccountResponse, error)
+	Create(ctx context.Context, account domain.Account) error
+	Update(ctx context.Context, changes domain.AccountChanges) error
+	Get(ctx context.Context, userId string) (*domain.Account, error)
+	Birthdays(ctx context.Context, from, to time.Time, limit, offset int) ([]domain.Birthday, error)
+	Genders() domain.Genders
 }
 
 type AccountRouter struct {
@@ -31,15 +37,20 @@ type AccountRouter struct {
 	logger         *slog.Logger
 	config         *config.ServerConfig
 	usecase        AccountUsecase
+	limiter        ratelimit.Limiter
+	// validate checks requests before they are mapped to domain
+	validate *validator.Validate
 }
 
 func NewAccountRouter(r *chi.Mux, cfg *config.ServerConfig,
-	log *slog.Logger, usecase AccountUsecase) *AccountRouter {
+	log *slog.Logger, usecase AccountUsecase, limiter ratelimit.Limiter) *AccountRouter {
 	router := &AccountRouter{
 		defaultHandler: r,
 		logger:         log,
 		config:         cfg,
 		usecase:        usecase,
+		limiter:        limiter,
+		validate:       validation.NewAccountValidator(usecase.Genders()),
 	}
 
 	return router
@@ -48,11 +59,37 @@ func NewAccountRouter(r *chi.Mux, cfg *config.ServerConfig,
 func ConfigureAccountRouter(r *AccountRouter) {
 	// Auth middleware
 	authMiddleware := auth.NewMiddleware(r.config.AuthServiceUrl)
+	userLogger := middleware.UserLogger(r.logger)
+	// Callers are limited before auth, users after it
+	clientLimit := r.clientRateLimit()
 
-	r.defaultHandler.With(authMiddleware.Handler).Post("/api/v1/account/create-account", r.CreateAccountHandler)
-	r.defaultHandler.With(authMiddleware.Handler).Get("/api/v1/account/get-account/{user_id}", r.GetAccountHandler)
-	// r.defaultHandler.Patch("/api/v1/account/update-account", r.UpdateAccountHandler)
-	// ...
+	r.defaultHandler.With(r.timeout("create-account"), clientLimit,
+		authMiddleware.Handler, userLogger, r.rateLimit("create-account")).
+		Post("/api/v1/account/create-account", r.CreateAccountHandler)
+	r.defaultHandler.With(r.timeout("update-account"), clientLimit,
+		authMiddleware.Handler, userLogger, r.rateLimit("update-account")).
+		Patch("/api/v1/account", r.UpdateAccountHandler)
+	r.defaultHandler.With(r.timeout("get-account"), clientLimit,
+		authMiddleware.Handler, userLogger, r.rateLimit("get-account")).
+		Get("/api/v1/account/get-account/{user_id}", r.GetAccountHandler)
+	r.defaultHandler.With(r.timeout("birthdays"), clientLimit, authMiddleware.Handler, userLogger,
+		middleware.RequireRole(roleAdmin, roleService), r.rateLimit("birthdays")).
+		Get("/api/v1/account/birthdays", r.BirthdaysHandler)
+	// Reference data is public
+	r.defaultHandler.With(r.timeout("genders"), r.rateLimit("genders")).
+		Get("/api/v1/account/genders", r.GendersHandler)
+}
+
+func (a *AccountRouter) rateLimit(route string) func(http.Handler) http.Handler {
+	return middleware.RateLimit(a.config.RateLimit, route, a.limiter, a.logger)
+}
+
+func (a *AccountRouter) clientRateLimit() func(http.Handler) http.Handler {
+	return middleware.ClientRateLimit(a.config.RateLimit, a.limiter, a.logger)
+}
+
+func (a *AccountRouter) timeout(route string) func(http.Handler) http.Handler {
+	return middleware.Timeout(a.config.Timeouts.Route(route))
 }
 
 // @Title GetAccount
@@ -66,15 +103,19 @@ func ConfigureAccountRouter(r *AccountRouter) {
 // @Success 200 {object} dtos.GetAccountResponse
 // @Failure 400 {object} dtos.Response
 // @Failure 404 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
 // @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
 // @Router /api/v1/account/get-account/{user_id} [get]
 func (a *AccountRouter) GetAccountHandler(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), time.Millisecond*100)
-	defer cancel()
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
 
 	userId := chi.URLParam(r, "user_id")
 	if userId == "" {
-		a.logger.Error("user_id param is empty")
+		logger.Error("user_id param is empty")
 
 		response.JSON(w, http.StatusBadRequest, "invalid request")
 		return
@@ -82,7 +123,9 @@ func (a *AccountRouter) GetAccountHandler(w http.ResponseWriter, r *http.Request
 
 	account, err := a.usecase.Get(ctx, userId)
 	if err != nil {
-		if strings.Contains(err.Error(), "failed") {
+		if status, msg, ok := middleware.DeadlineStatus(ctx); ok {
+			response.JSON(w, status, msg)
+		} else if strings.Contains(err.Error(), "failed") {
 			response.JSON(w, http.StatusInternalServerError, err.Error())
 		} else {
 			response.JSON(w, http.StatusBadRequest, err.Error())
@@ -91,7 +134,7 @@ func (a *AccountRouter) GetAccountHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	response.JSON(w, http.StatusOK, account)
+	response.JSON(w, http.StatusOK, dtos.NewGetAccountResponse(*account, time.Now()))
 }
 
 // @Title CreateAccount
@@ -102,13 +145,18 @@ func (a *AccountRouter) GetAccountHandler(w http.ResponseWriter, r *http.Request
 // @Produce json
 // @Security ApiKeyAuth
 // @Param request body dtos.CreateAccountRequest true "Account creation data"
+// @Param Accept-Language header string false "Language of validation messages" Enums(en, ru)
 // @Success 201 {object} dtos.Response
-// @Failure 400 {object} dtos.Response
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 429 {object} dtos.Response
 // @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
 // @Router /api/v1/account/create-account [post]
 func (a *AccountRouter) CreateAccountHandler(w http.ResponseWriter, r *http.Request) {
-	ctx, cancel := context.WithTimeout(r.Context(), time.Millisecond*100)
-	defer cancel()
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
 
 	var request dtos.CreateAccountRequest
 
@@ -117,43 +165,125 @@ func (a *AccountRouter) CreateAccountHandler(w http.ResponseWriter, r *http.Requ
 	if err != nil {
 		// EOF means there is no data in the request body
 		if errors.Is(err, io.EOF) {
-			a.logger.Error("request body is empty", slogerr.Error(err))
+			logger.Error("request body is empty", slogerr.Error(err))
 			response.JSON(w, http.StatusBadRequest, "request body is empty")
 			return
 		}
 
-		a.logger.Error("failed to decode request body", slogerr.Error(err))
+		// Date error message tells client expected format
+		var dateErr *dtos.DateError
+		if errors.As(err, &dateErr) {
+			response.JSON(w, http.StatusBadRequest, "birthdate: "+dateErr.Error())
+			return
+		}
+
+		logger.Error("failed to decode request body", slogerr.Error(err))
 		response.JSON(w, http.StatusBadRequest, "failed to decode request body")
 		return
 	}
 
-	// Validate request fields
-	err = validator.New().Struct(request)
+	// Get user id from request context
+	userIdAsString := r.Context().Value("user_id").(string)
+	request.UserId, err = uuid.Parse(userIdAsString)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "unable to parse uuid from user_id")
+		return
+	}
+
+	if !validRequest(w, r, a.validate, request) {
+		return
+	}
+
+	account, err := request.ToDomain(a.usecase.Genders())
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = a.usecase.Create(ctx, account)
 	if err != nil {
-		var errors []string
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			for _, fieldErr := range validationErrors {
-				errors = append(errors, getValidationMsg(fieldErr))
-			}
+		if status, msg, ok := middleware.DeadlineStatus(ctx); ok {
+			response.JSON(w, status, msg)
+		} else if strings.Contains(err.Error(), "failed") {
+			response.JSON(w, http.StatusInternalServerError, err.Error())
+		} else {
+			response.JSON(w, http.StatusBadRequest, err.Error())
 		}
 
-		resp := map[string]any{"errors": errors}
+		return
+	}
+}
 
-		response.JSON(w, http.StatusBadRequest, resp)
+// @Title UpdateAccount
+// @Summary Update own account
+// @Description Changes only fields present in request. Empty profile field or contact unsets it,
+// @Description changed contact must be verified again.
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dtos.UpdateAccountRequest true "Changed account fields"
+// @Param Accept-Language header string false "Language of validation messages" Enums(en, ru)
+// @Success 200 {object} dtos.Response
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 404 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
+// @Router /api/v1/account [patch]
+func (a *AccountRouter) UpdateAccountHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
+
+	var request dtos.UpdateAccountRequest
+
+	err := render.DecodeJSON(r.Body, &request)
+	if err != nil {
+		// EOF means there is no data in the request body
+		if errors.Is(err, io.EOF) {
+			logger.Error("request body is empty", slogerr.Error(err))
+			response.JSON(w, http.StatusBadRequest, "request body is empty")
+			return
+		}
+
+		// Date error message tells client expected format
+		var dateErr *dtos.DateError
+		if errors.As(err, &dateErr) {
+			response.JSON(w, http.StatusBadRequest, "birthdate: "+dateErr.Error())
+			return
+		}
+
+		logger.Error("failed to decode request body", slogerr.Error(err))
+		response.JSON(w, http.StatusBadRequest, "failed to decode request body")
 		return
 	}
 
-	// Get user id from request context
+	// Users change only their own account
 	userIdAsString := r.Context().Value("user_id").(string)
 	request.UserId, err = uuid.Parse(userIdAsString)
 	if err != nil {
 		response.JSON(w, http.StatusBadRequest, "unable to parse uuid from user_id")
+		return
+	}
+
+	if !validRequest(w, r, a.validate, request) {
+		return
 	}
 
-	err = a.usecase.Create(ctx, request)
+	changes, err := request.ToDomain(a.usecase.Genders())
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			response.JSON(w, http.StatusRequestTimeout, err.Error())
+		response.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = a.usecase.Update(ctx, changes)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			response.JSON(w, http.StatusNotFound, err.Error())
+		} else if status, msg, ok := middleware.DeadlineStatus(ctx); ok {
+			response.JSON(w, status, msg)
 		} else if strings.Contains(err.Error(), "failed") {
 			response.JSON(w, http.StatusInternalServerError, err.Error())
 		} else {
@@ -162,17 +292,98 @@ func (a *AccountRouter) CreateAccountHandler(w http.ResponseWriter, r *http.Requ
 
 		return
 	}
+
+	response.JSON(w, http.StatusOK, "account updated")
 }
 
-func getValidationMsg(fe validator.FieldError) string {
-	switch fe.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", fe.Field())
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", fe.Field(), fe.Param())
-	case "max":
-		return fmt.Sprintf("%s must be at most %s characters", fe.Field(), fe.Param())
+// @Title Birthdays
+// @Summary List accounts with birthdays in date range
+// @Description Returns accounts with birthdays on days from..to inclusive in order of celebration.
+// @Description Range wraps year end and must not be longer than a year.
+// @Description Those born on February 29 celebrate on March 1 in non-leap years.
+// @Tags Account
+// @Produce json
+// @Security ApiKeyAuth
+// @Param from query string true "First day of range" format(date) example(2025-12-25)
+// @Param to query string true "Last day of range" format(date) example(2026-01-07)
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset"
+// @Param Accept-Language header string false "Language of validation messages" Enums(en, ru)
+// @Success 200 {array} dtos.BirthdayResponse
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
+// @Router /api/v1/account/birthdays [get]
+func (a *AccountRouter) BirthdaysHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+
+	var (
+		request dtos.BirthdaysRequest
+		err     error
+	)
+
+	if request.From, err = dtos.ParseDate(query.Get("from")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "from: "+err.Error())
+		return
+	}
+	if request.To, err = dtos.ParseDate(query.Get("to")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "to: "+err.Error())
+		return
+	}
+	if request.Limit, err = intQueryParam(query.Get("limit")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if request.Offset, err = intQueryParam(query.Get("offset")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	if !validRequest(w, r, a.validate, request) {
+		return
+	}
+
+	birthdays, err := a.usecase.Birthdays(ctx, request.From.Time(), request.To.Time(), request.Limit, request.Offset)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidBirthdayRange) {
+			response.JSON(w, http.StatusBadRequest, err.Error())
+		} else if status, msg, ok := middleware.DeadlineStatus(ctx); ok {
+			response.JSON(w, status, msg)
+		} else {
+			response.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dtos.NewBirthdayResponses(birthdays, time.Now()))
+}
+
+// @Title Genders
+// @Summary List allowed genders
+// @Description Returns gender codes accepted in accounts with labels in requested language.
+// @Description Language is taken from lang parameter or Accept-Language header, English by default.
+// @Tags Account
+// @Produce json
+// @Param lang query string false "Label language" Enums(en, ru)
+// @Param Accept-Language header string false "Label language"
+// @Success 200 {array} dtos.GenderResponse
+// @Failure 429 {object} dtos.Response
+// @Router /api/v1/account/genders [get]
+func (a *AccountRouter) GendersHandler(w http.ResponseWriter, r *http.Request) {
+	lang := requestLanguage(r)
+
+	genders := a.usecase.Genders()
+	result := make([]dtos.GenderResponse, 0, len(genders))
+	for _, g := range genders {
+		result = append(result, dtos.GenderResponse{Code: g.Code, Label: g.Label(lang)})
 	}
 
-	return "validation error"
+	w.Header().Set("Content-Language", lang)
+	response.JSON(w, http.StatusOK, result)
 }
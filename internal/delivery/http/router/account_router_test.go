@@ -0,0 +1,127 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+// fakeAccountUsecase records created accounts
+type fakeAccountUsecase struct {
+	AccountUsecase
+	genders domain.Genders
+	created []domain.Account
+}
+
+func (u *fakeAccountUsecase) Create(_ context.Context, account domain.Account) error {
+	u.created = append(u.created, account)
+	return nil
+}
+
+func (u *fakeAccountUsecase) Birthdays(context.Context, time.Time, time.Time, int, int) ([]domain.Birthday, error) {
+	return nil, nil
+}
+
+func (u *fakeAccountUsecase) Genders() domain.Genders {
+	return u.genders
+}
+
+func newTestAccountRouter(t *testing.T) (*AccountRouter, *fakeAccountUsecase) {
+	t.Helper()
+
+	genders, err := domain.NewGenderCatalog()
+	if err != nil {
+		t.Fatalf("NewGenderCatalog() error = %v", err)
+	}
+
+	usecase := &fakeAccountUsecase{genders: genders}
+	return &AccountRouter{
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		usecase:  usecase,
+		validate: validation.NewAccountValidator(genders),
+	}, usecase
+}
+
+func responseMessage(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response body %q: %v", rec.Body.String(), err)
+	}
+
+	return body.Message
+}
+
+func TestCreateAccountHandlerRejectsInvalidDate(t *testing.T) {
+	birthdates := []string{`"1990-1-1"`, `"1990-02-30"`, `"1990-01-01T00:00:00Z"`, `19900101`}
+
+	for _, birthdate := range birthdates {
+		t.Run(birthdate, func(t *testing.T) {
+			router, usecase := newTestAccountRouter(t)
+
+			body := `{"firstname":"Иван","surname":"Иванов","gender":"M","birthdate":` + birthdate + `}`
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/account/create-account", strings.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), "user_id", uuid.NewString()))
+			rec := httptest.NewRecorder()
+
+			router.CreateAccountHandler(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			if msg := responseMessage(t, rec); !strings.HasPrefix(msg, "birthdate: ") || !strings.Contains(msg, "YYYY-MM-DD") {
+				t.Errorf("message = %q, want birthdate format error", msg)
+			}
+			if len(usecase.created) != 0 {
+				t.Errorf("created = %+v, want none", usecase.created)
+			}
+		})
+	}
+}
+
+func TestCreateAccountHandlerAcceptsDate(t *testing.T) {
+	router, usecase := newTestAccountRouter(t)
+
+	body := `{"firstname":"Иван","surname":"Иванов","gender":"M","birthdate":"1990-01-31"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/account/create-account", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), "user_id", uuid.NewString()))
+	rec := httptest.NewRecorder()
+
+	router.CreateAccountHandler(rec, req)
+
+	if len(usecase.created) != 1 {
+		t.Fatalf("status = %d, body = %s, want account created", rec.Code, rec.Body)
+	}
+	if got := usecase.created[0].Birthdate; !got.Equal(time.Date(1990, time.January, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("birthdate = %v, want 1990-01-31", got)
+	}
+}
+
+func TestBirthdaysHandlerRejectsInvalidDate(t *testing.T) {
+	router, _ := newTestAccountRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/account/birthdays?from=2025-02-30&to=2025-03-01", nil)
+	rec := httptest.NewRecorder()
+
+	router.BirthdaysHandler(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if msg := responseMessage(t, rec); !strings.HasPrefix(msg, "from: ") {
+		t.Errorf("message = %q, want from date error", msg)
+	}
+}
@@ -0,0 +1,401 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/delivery/http/middleware"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+	"github.com/WebChads/AccountService/internal/pkg/bulk"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
+	"github.com/WebChads/AccountService/internal/usecase"
+	"github.com/WebChads/AccountService/pkg/middleware/auth"
+	"github.com/go-chi/chi"
+	"github.com/go-playground/validator"
+)
+
+const (
+	roleAdmin = "admin"
+	// roleService is role of internal services, e.g. greetings service
+	roleService = "service"
+	// roleSupport is role of support staff looking up users
+	roleSupport = "support"
+
+	// maxImportRejects limits rejects returned in response body
+	maxImportRejects = 1000
+)
+
+// noDeadline resets connection deadline
+var noDeadline = time.Time{}
+
+type AdminUsecase interface {
+	Import(ctx context.Context, reader bulk.Reader, opts usecase.ImportOptions) (dtos.ImportResult, error)
+	Export(ctx context.Context, filter domain.AccountFilter, maskPII bool, writer bulk.Writer) (int, error)
+	Search(ctx context.Context, filter domain.AccountFilter) ([]domain.Account, error)
+	FuzzySearch(ctx context.Context, query string, limit, offset int) ([]domain.AccountMatch, error)
+	Genders() domain.Genders
+}
+
+type AdminRouter struct {
+	defaultHandler *chi.Mux
+	logger         *slog.Logger
+	config         *config.ServerConfig
+	usecase        AdminUsecase
+	limiter        ratelimit.Limiter
+	// validate checks requests before they are mapped to domain
+	validate *validator.Validate
+}
+
+func NewAdminRouter(r *chi.Mux, cfg *config.ServerConfig,
+	log *slog.Logger, usecase AdminUsecase, limiter ratelimit.Limiter) *AdminRouter {
+	router := &AdminRouter{
+		defaultHandler: r,
+		logger:         log,
+		config:         cfg,
+		usecase:        usecase,
+		limiter:        limiter,
+		validate:       validation.NewAccountValidator(usecase.Genders()),
+	}
+
+	return router
+}
+
+func ConfigureAdminRouter(r *AdminRouter) {
+	// Auth middleware
+	authMiddleware := auth.NewMiddleware(r.config.AuthServiceUrl)
+	userLogger := middleware.UserLogger(r.logger)
+	adminOnly := middleware.RequireRole(roleAdmin)
+	// Callers are limited before auth
+	clientLimit := middleware.ClientRateLimit(r.config.RateLimit, r.limiter, r.logger)
+
+	r.defaultHandler.With(middleware.Timeout(r.config.Timeouts.Route("search-accounts")), clientLimit,
+		authMiddleware.Handler, userLogger, adminOnly).
+		Get("/api/v1/admin/accounts", r.SearchAccountsHandler)
+	r.defaultHandler.With(middleware.Timeout(r.config.Timeouts.Route("fuzzy-search-accounts")), clientLimit,
+		authMiddleware.Handler, userLogger, middleware.RequireRole(roleAdmin, roleSupport)).
+		Get("/api/v1/admin/accounts/search", r.FuzzySearchAccountsHandler)
+
+	// Bulk routes are long-running, so they have no request deadline
+	r.defaultHandler.With(clientLimit, authMiddleware.Handler, userLogger, adminOnly).
+		Post("/api/v1/admin/accounts/import", r.ImportAccountsHandler)
+	r.defaultHandler.With(clientLimit, authMiddleware.Handler, userLogger, adminOnly).
+		Get("/api/v1/admin/accounts/export", r.ExportAccountsHandler)
+}
+
+// @Title ImportAccounts
+// @Summary Bulk import accounts
+// @Description Streams CSV or NDJSON rows through account validation and inserts them in batches.
+// @Description Rejected rows are returned in response, use last_line as resume_from to continue interrupted import.
+// @Description Only first 1000 rejects are returned, truncated is set then and rejected holds total count.
+// @Tags Admin
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Security ApiKeyAuth
+// @Param format query string true "Input format" Enums(csv, ndjson)
+// @Param batch_size query int false "Rows per batch" default(1000)
+// @Param resume_from query int false "Skip rows up to this line"
+// @Success 200 {object} dtos.ImportResult
+// @Failure 400 {object} dtos.Response
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/admin/accounts/import [post]
+func (a *AdminRouter) ImportAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
+
+	query := r.URL.Query()
+
+	batchSize, err := intQueryParam(query.Get("batch_size"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid batch_size")
+		return
+	}
+
+	resumeFrom, err := intQueryParam(query.Get("resume_from"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid resume_from")
+		return
+	}
+
+	reader, err := bulk.NewReader(r.Body, query.Get("format"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Large uploads take longer than server timeouts
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(noDeadline); err != nil {
+		logger.Warn("failed to reset read deadline", slogerr.Warn(err))
+	}
+	if err := rc.SetWriteDeadline(noDeadline); err != nil {
+		logger.Warn("failed to reset write deadline", slogerr.Warn(err))
+	}
+
+	var rejects []dtos.ImportReject
+	result, err := a.usecase.Import(ctx, reader, usecase.ImportOptions{
+		BatchSize: batchSize,
+		SkipLines: resumeFrom,
+		Language:  requestLanguage(r),
+		OnReject: func(reject dtos.ImportReject) error {
+			if len(rejects) < maxImportRejects {
+				rejects = append(rejects, reject)
+			}
+			return nil
+		},
+	})
+	result.Rejects = rejects
+	result.Truncated = result.Rejected > len(rejects)
+	if err != nil {
+		logger.Error("import accounts", slogerr.Error(err))
+		// Committed batches stay, so caller can resume from last_line
+		response.JSON(w, http.StatusInternalServerError, result)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, result)
+}
+
+// @Title ExportAccounts
+// @Summary Bulk export accounts
+// @Description Streams accounts matching filters as CSV, NDJSON or Parquet file
+// @Tags Admin
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.apache.parquet
+// @Security ApiKeyAuth
+// @Param format query string false "Output format" Enums(csv, ndjson, parquet) default(csv)
+// @Param created_from query string false "Created at or after (RFC 3339 or YYYY-MM-DD)"
+// @Param created_to query string false "Created before (RFC 3339 or YYYY-MM-DD)"
+// @Param gender query string false "Gender"
+// @Param mask_pii query bool false "Mask names and birth dates"
+// @Param Accept-Language header string false "Language of validation messages" Enums(en, ru)
+// @Success 200 {file} file
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/admin/accounts/export [get]
+func (a *AdminRouter) ExportAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
+
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = bulk.FormatCSV
+	}
+
+	createdFrom, err := bulk.ParseTime(query.Get("created_from"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	createdTo, err := bulk.ParseTime(query.Get("created_to"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	maskPII := false
+	if value := query.Get("mask_pii"); value != "" {
+		maskPII, err = strconv.ParseBool(value)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, "invalid mask_pii")
+			return
+		}
+	}
+
+	req := dtos.ExportAccountsRequest{
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		Gender:      query.Get("gender"),
+		MaskPII:     maskPII,
+	}
+	if !validRequest(w, r, a.validate, req) {
+		return
+	}
+
+	filter, err := req.ToDomain(a.usecase.Genders())
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writer, err := bulk.NewWriter(w, format)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Export of whole table takes longer than server write timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(noDeadline); err != nil {
+		logger.Warn("failed to reset write deadline", slogerr.Warn(err))
+	}
+
+	w.Header().Set("Content-Type", bulk.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="accounts.%s"`, format))
+
+	exported, err := a.usecase.Export(ctx, filter, req.MaskPII, writer)
+	if err != nil && exported == 0 {
+		// Nothing is sent yet, so proper error response is still possible
+		w.Header().Del("Content-Disposition")
+
+		logger.Error("export accounts", slogerr.Error(err))
+		response.JSON(w, http.StatusInternalServerError, "failed to export accounts")
+		return
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		logger.Error("export accounts", slogerr.Error(err))
+		// Body is partially sent, abort connection so client sees truncated file
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// @Title SearchAccounts
+// @Summary List accounts
+// @Description Lists accounts ordered by name. Names match case-insensitively in either
+// @Description Cyrillic or Latin script, e.g. "ivanov" finds "Иванов".
+// @Tags Admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param surname query string false "Surname prefix" example(ivan)
+// @Param firstname query string false "First name" example(Иван)
+// @Param gender query string false "Gender"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset"
+// @Param Accept-Language header string false "Language of validation messages" Enums(en, ru)
+// @Success 200 {array} dtos.GetAccountResponse
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
+// @Router /api/v1/admin/accounts [get]
+func (a *AdminRouter) SearchAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+
+	request := dtos.SearchAccountsRequest{
+		Surname:   query.Get("surname"),
+		Firstname: query.Get("firstname"),
+		Gender:    query.Get("gender"),
+	}
+
+	var err error
+	if request.Limit, err = intQueryParam(query.Get("limit")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if request.Offset, err = intQueryParam(query.Get("offset")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	if !validRequest(w, r, a.validate, request) {
+		return
+	}
+
+	filter, err := request.ToDomain(a.usecase.Genders())
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	accounts, err := a.usecase.Search(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidGender) {
+			response.JSON(w, http.StatusBadRequest, err.Error())
+		} else if status, msg, ok := middleware.DeadlineStatus(ctx); ok {
+			response.JSON(w, status, msg)
+		} else {
+			response.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dtos.NewGetAccountResponses(accounts, time.Now()))
+}
+
+// @Title FuzzySearchAccounts
+// @Summary Search accounts by full name
+// @Description Finds accounts by any part of full name tolerating typos, in either Cyrillic or
+// @Description Latin script, e.g. "ivnov ivan" finds "Иванов Иван". Best matches come first.
+// @Tags Admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string true "Full name or its part" example(ivnov ivan)
+// @Param limit query int false "Page size" default(50) maximum(100)
+// @Param offset query int false "Page offset"
+// @Param Accept-Language header string false "Language of validation messages" Enums(en, ru)
+// @Success 200 {array} dtos.AccountMatchResponse
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
+// @Router /api/v1/admin/accounts/search [get]
+func (a *AdminRouter) FuzzySearchAccountsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+
+	request := dtos.FuzzySearchAccountsRequest{Query: query.Get("q")}
+
+	var err error
+	if request.Limit, err = intQueryParam(query.Get("limit")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if request.Offset, err = intQueryParam(query.Get("offset")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	if !validRequest(w, r, a.validate, request) {
+		return
+	}
+
+	matches, err := a.usecase.FuzzySearch(ctx, request.Query, request.Limit, request.Offset)
+	if err != nil {
+		if status, msg, ok := middleware.DeadlineStatus(ctx); ok {
+			response.JSON(w, status, msg)
+		} else {
+			response.JSON(w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dtos.NewAccountMatchResponses(matches, time.Now()))
+}
+
+func intQueryParam(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(value)
+}
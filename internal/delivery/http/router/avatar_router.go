@@ -0,0 +1,240 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/WebChads/AccountService/internal/blob"
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/delivery/http/middleware"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+	"github.com/WebChads/AccountService/internal/pkg/imaging"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+	"github.com/WebChads/AccountService/pkg/middleware/auth"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+// multipartOverhead is room for multipart boundaries and part headers
+const multipartOverhead = 64 << 10
+
+type AvatarUsecase interface {
+	Upload(ctx context.Context, userId uuid.UUID, r io.Reader) (*domain.Avatar, error)
+	Delete(ctx context.Context, userId uuid.UUID) error
+	Open(ctx context.Context, key string) (io.ReadCloser, blob.Info, error)
+	MaxSize() int64
+}
+
+type AvatarRouter struct {
+	defaultHandler *chi.Mux
+	logger         *slog.Logger
+	config         *config.ServerConfig
+	usecase        AvatarUsecase
+	limiter        ratelimit.Limiter
+}
+
+func NewAvatarRouter(r *chi.Mux, cfg *config.ServerConfig,
+	log *slog.Logger, usecase AvatarUsecase, limiter ratelimit.Limiter) *AvatarRouter {
+	router := &AvatarRouter{
+		defaultHandler: r,
+		logger:         log,
+		config:         cfg,
+		usecase:        usecase,
+		limiter:        limiter,
+	}
+
+	return router
+}
+
+func ConfigureAvatarRouter(r *AvatarRouter) {
+	// Auth middleware
+	authMiddleware := auth.NewMiddleware(r.config.AuthServiceUrl)
+	userLogger := middleware.UserLogger(r.logger)
+	// Callers are limited before auth, users after it
+	clientLimit := r.clientRateLimit()
+
+	r.defaultHandler.With(r.timeout("upload-avatar"), clientLimit,
+		authMiddleware.Handler, userLogger, r.rateLimit("upload-avatar")).
+		Put("/api/v1/account/avatar", r.UploadAvatarHandler)
+	r.defaultHandler.With(r.timeout("delete-avatar"), clientLimit,
+		authMiddleware.Handler, userLogger, r.rateLimit("delete-avatar")).
+		Delete("/api/v1/account/avatar", r.DeleteAvatarHandler)
+	// Avatar URLs are public, keys contain random avatar id
+	r.defaultHandler.With(r.timeout("avatar-files"), r.rateLimit("avatar-files")).
+		Get("/api/v1/account/avatars/*", r.AvatarFileHandler)
+}
+
+func (a *AvatarRouter) rateLimit(route string) func(http.Handler) http.Handler {
+	return middleware.RateLimit(a.config.RateLimit, route, a.limiter, a.logger)
+}
+
+func (a *AvatarRouter) clientRateLimit() func(http.Handler) http.Handler {
+	return middleware.ClientRateLimit(a.config.RateLimit, a.limiter, a.logger)
+}
+
+func (a *AvatarRouter) timeout(route string) func(http.Handler) http.Handler {
+	return middleware.Timeout(a.config.Timeouts.Route(route))
+}
+
+// @Title UploadAvatar
+// @Summary Upload avatar
+// @Description Replaces avatar of current account. JPEG, PNG, GIF and WebP are accepted, type is detected from content.
+// @Description Image is cropped to center square and stored as thumbnails of configured sizes without EXIF and other metadata.
+// @Tags Avatar
+// @Accept mpfd
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "Image file"
+// @Success 200 {object} dtos.AvatarResponse
+// @Failure 400 {object} dtos.Response
+// @Failure 404 {object} dtos.Response
+// @Failure 413 {object} dtos.Response
+// @Failure 415 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
+// @Router /api/v1/account/avatar [put]
+func (a *AvatarRouter) UploadAvatarHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
+
+	userId, err := uuid.Parse(ctx.Value("user_id").(string))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "unable to parse uuid from user_id")
+		return
+	}
+
+	// Whole body is limited, so parts before file can't be used to exhaust memory
+	r.Body = http.MaxBytesReader(w, r.Body, a.usecase.MaxSize()+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "request must be multipart/form-data")
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			response.JSON(w, http.StatusBadRequest, "file is missing")
+			return
+		}
+		if err != nil {
+			a.error(w, r, err)
+			return
+		}
+
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		avatar, err := a.usecase.Upload(ctx, userId, part)
+		part.Close()
+		if err != nil {
+			logger.Warn("upload avatar", slogerr.Error(err))
+			a.error(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, dtos.NewAvatarResponse(*avatar))
+		return
+	}
+}
+
+// @Title DeleteAvatar
+// @Summary Delete avatar
+// @Description Removes avatar of current account with all its thumbnails
+// @Tags Avatar
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dtos.Response
+// @Failure 404 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
+// @Router /api/v1/account/avatar [delete]
+func (a *AvatarRouter) DeleteAvatarHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userId, err := uuid.Parse(ctx.Value("user_id").(string))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "unable to parse uuid from user_id")
+		return
+	}
+
+	if err = a.usecase.Delete(ctx, userId); err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, "avatar deleted")
+}
+
+// @Title AvatarFile
+// @Summary Get avatar thumbnail
+// @Description Serves avatar file by key from avatar URL, content never changes for the key
+// @Tags Avatar
+// @Produce image/jpeg,image/png
+// @Param key path string true "Blob key, e.g. <user id>/<avatar id>/128.jpg"
+// @Success 200 {file} file
+// @Failure 404 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/account/avatars/{key} [get]
+func (a *AvatarRouter) AvatarFileHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	file, info, err := a.usecase.Open(ctx, chi.URLParam(r, "*"))
+	if err != nil {
+		a.error(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	h := w.Header()
+	h.Set("Content-Type", info.ContentType)
+	h.Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	h.Set("X-Content-Type-Options", "nosniff")
+	// New avatar gets new key, so files are cached forever
+	h.Set("Cache-Control", "public, max-age=31536000, immutable")
+
+	if _, err = io.Copy(w, file); err != nil {
+		slogerr.FromContext(ctx, a.logger).Warn("serve avatar file", slogerr.Error(err))
+	}
+}
+
+// error maps usecase error to response
+func (a *AvatarRouter) error(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesErr), errors.Is(err, domain.ErrAvatarTooLarge):
+		response.JSON(w, http.StatusRequestEntityTooLarge, domain.ErrAvatarTooLarge.Error())
+	case errors.Is(err, imaging.ErrTooManyPixels):
+		response.JSON(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, imaging.ErrUnsupportedFormat):
+		response.JSON(w, http.StatusUnsupportedMediaType, err.Error())
+	case errors.Is(err, imaging.ErrInvalidImage):
+		response.JSON(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrAccountNotFound), errors.Is(err, domain.ErrAvatarNotSet),
+		errors.Is(err, blob.ErrNotFound), errors.Is(err, blob.ErrInvalidKey):
+		response.JSON(w, http.StatusNotFound, err.Error())
+	default:
+		if status, msg, ok := middleware.DeadlineStatus(r.Context()); ok {
+			response.JSON(w, status, msg)
+			return
+		}
+		response.JSON(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
+	"github.com/go-playground/validator"
+	"golang.org/x/text/language"
+)
+
+// languageMatcher matches languages of labels and messages, the first is default
+var languageMatcher = language.NewMatcher([]language.Tag{
+	language.English,
+	language.Russian,
+})
+
+// requestLanguage returns base language requested by lang query param
+// or Accept-Language header, e.g. "ru"
+func requestLanguage(r *http.Request) string {
+	tag, _ := language.MatchStrings(languageMatcher, r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+	base, _ := tag.Base()
+
+	return base.String()
+}
+
+// validationFailed responds with per-field validation errors in requested language
+func validationFailed(w http.ResponseWriter, r *http.Request, errs validator.ValidationErrors) {
+	lang := requestLanguage(r)
+
+	w.Header().Set("Content-Language", lang)
+	response.JSON(w, http.StatusBadRequest, dtos.ValidationErrors{Errors: validation.Errors(errs, lang)})
+}
+
+// validRequest responds with validation errors of request, it reports whether request is valid
+func validRequest(w http.ResponseWriter, r *http.Request, v *validator.Validate, request any) bool {
+	err := v.Struct(request)
+	if err == nil {
+		return true
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		validationFailed(w, r, validationErrors)
+	} else {
+		response.JSON(w, http.StatusInternalServerError, "failed to validate request: "+err.Error())
+	}
+
+	return false
+}
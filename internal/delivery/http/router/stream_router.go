@@ -0,0 +1,227 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/delivery/http/middleware"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/models/events"
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+	"github.com/WebChads/AccountService/internal/stream"
+	"github.com/WebChads/AccountService/pkg/middleware/auth"
+	"github.com/go-chi/chi"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultHeartbeat = 15 * time.Second
+
+	// eventReset tells client that events were missed and state must be reloaded
+	eventReset = "reset"
+)
+
+type EventBroker interface {
+	Subscribe(key string, lastEventId *int64) (*stream.Subscription, []events.Message, bool)
+	Unsubscribe(s *stream.Subscription)
+}
+
+type StreamRouter struct {
+	defaultHandler *chi.Mux
+	logger         *slog.Logger
+	config         *config.ServerConfig
+	broker         EventBroker
+	limiter        ratelimit.Limiter
+}
+
+func NewStreamRouter(r *chi.Mux, cfg *config.ServerConfig,
+	log *slog.Logger, broker EventBroker, limiter ratelimit.Limiter) *StreamRouter {
+	router := &StreamRouter{
+		defaultHandler: r,
+		logger:         log,
+		config:         cfg,
+		broker:         broker,
+		limiter:        limiter,
+	}
+
+	return router
+}
+
+func ConfigureStreamRouter(r *StreamRouter) {
+	// Auth middleware
+	authMiddleware := auth.NewMiddleware(r.config.AuthServiceUrl)
+	userLogger := middleware.UserLogger(r.logger)
+
+	// Stream is long-lived, so it has no request deadline.
+	// Callers are limited before auth, reconnects count as requests.
+	r.defaultHandler.With(middleware.ClientRateLimit(r.config.RateLimit, r.limiter, r.logger),
+		authMiddleware.Handler, userLogger).
+		Get("/api/v1/account/events", r.AccountEventsHandler)
+}
+
+// @Title AccountEvents
+// @Summary Stream account changes
+// @Description Server-Sent Events stream of account.created, account.updated and account.deleted events.
+// @Description Users receive only their own account events, admins receive all events unless user_id is set.
+// @Description Reconnect with Last-Event-ID header (or last_event_id param) to resume; "reset" event
+// @Description means resume is not possible and account state must be reloaded.
+// @Tags Account
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param user_id query string false "Account to watch, defaults to own account for non-admins"
+// @Param last_event_id query int false "Resume after event, same as Last-Event-ID header"
+// @Success 200 {object} dtos.AccountEvent "event stream, data of every event"
+// @Failure 400 {object} dtos.Response
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Router /api/v1/account/events [get]
+func (a *StreamRouter) AccountEventsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
+
+	key, status, msg := eventsKey(r)
+	if status != 0 {
+		response.JSON(w, status, msg)
+		return
+	}
+
+	lastEventId, err := lastEventID(r)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid last event id")
+		return
+	}
+
+	// Stream outlives server write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(noDeadline); err != nil {
+		logger.Warn("failed to reset write deadline", slogerr.Warn(err))
+	}
+
+	subscription, replay, resumed := a.broker.Subscribe(key, lastEventId)
+	defer a.broker.Unsubscribe(subscription)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Disable proxy buffering
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if !resumed {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", eventReset)
+	}
+	for _, msg := range replay {
+		if err := writeEvent(w, msg); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		logger.Error("streaming is not supported", slogerr.Error(err))
+		return
+	}
+
+	heartbeat := time.Duration(a.config.Stream.Heartbeat)
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-subscription.C:
+			if !ok {
+				// Subscriber fell behind, client reconnects and resumes from log
+				logger.Warn("event stream subscriber is too slow, closing stream")
+				return
+			}
+			if err := writeEvent(w, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			// Comment line keeps connection open through proxies
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// eventsKey returns user id to filter events by, empty key means all events
+func eventsKey(r *http.Request) (key string, status int, msg string) {
+	userId, _ := r.Context().Value("user_id").(string)
+	role, _ := r.Context().Value("user_role").(string)
+
+	key = r.URL.Query().Get("user_id")
+	if key != "" {
+		id, err := uuid.Parse(key)
+		if err != nil {
+			return "", http.StatusBadRequest, "invalid user_id"
+		}
+		// Outbox keys are canonical uuid strings
+		key = id.String()
+	}
+
+	if role == roleAdmin {
+		return key, 0, ""
+	}
+
+	if key == "" {
+		return userId, 0, ""
+	}
+	if key != userId {
+		return "", http.StatusForbidden, "forbidden"
+	}
+
+	return key, 0, ""
+}
+
+// lastEventID reads Last-Event-ID header, set by EventSource on reconnect
+func lastEventID(r *http.Request) (*int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func writeEvent(w http.ResponseWriter, msg events.Message) error {
+	userId, _ := uuid.Parse(msg.Key)
+
+	data, err := json.Marshal(dtos.AccountEvent{
+		ID:        msg.ID,
+		Type:      msg.Type,
+		UserId:    userId,
+		Account:   msg.Payload,
+		CreatedAt: msg.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", msg.ID, msg.Type, data)
+	return err
+}
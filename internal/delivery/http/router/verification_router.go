@@ -0,0 +1,209 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/delivery/http/middleware"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+	"github.com/WebChads/AccountService/pkg/middleware/auth"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type VerificationUsecase interface {
+	Send(ctx context.Context, req dtos.SendVerificationRequest) (*domain.Verification, error)
+	Confirm(ctx context.Context, req dtos.ConfirmVerificationRequest) error
+	ResendCooldown() time.Duration
+}
+
+type VerificationRouter struct {
+	defaultHandler *chi.Mux
+	logger         *slog.Logger
+	config         *config.ServerConfig
+	usecase        VerificationUsecase
+	limiter        ratelimit.Limiter
+}
+
+func NewVerificationRouter(r *chi.Mux, cfg *config.ServerConfig,
+	log *slog.Logger, usecase VerificationUsecase, limiter ratelimit.Limiter) *VerificationRouter {
+	router := &VerificationRouter{
+		defaultHandler: r,
+		logger:         log,
+		config:         cfg,
+		usecase:        usecase,
+		limiter:        limiter,
+	}
+
+	return router
+}
+
+func ConfigureVerificationRouter(r *VerificationRouter) {
+	// Auth middleware
+	authMiddleware := auth.NewMiddleware(r.config.AuthServiceUrl)
+	userLogger := middleware.UserLogger(r.logger)
+	// Callers are limited before auth, users after it
+	clientLimit := r.clientRateLimit()
+
+	r.defaultHandler.With(r.timeout("send-verification"), clientLimit,
+		authMiddleware.Handler, userLogger, r.rateLimit("send-verification")).
+		Post("/api/v1/account/verification/{channel}/send", r.SendVerificationHandler)
+	r.defaultHandler.With(r.timeout("confirm-verification"), clientLimit,
+		authMiddleware.Handler, userLogger, r.rateLimit("confirm-verification")).
+		Post("/api/v1/account/verification/{channel}/confirm", r.ConfirmVerificationHandler)
+}
+
+func (a *VerificationRouter) rateLimit(route string) func(http.Handler) http.Handler {
+	return middleware.RateLimit(a.config.RateLimit, route, a.limiter, a.logger)
+}
+
+func (a *VerificationRouter) clientRateLimit() func(http.Handler) http.Handler {
+	return middleware.ClientRateLimit(a.config.RateLimit, a.limiter, a.logger)
+}
+
+func (a *VerificationRouter) timeout(route string) func(http.Handler) http.Handler {
+	return middleware.Timeout(a.config.Timeouts.Route(route))
+}
+
+// @Title SendVerification
+// @Summary Send verification code
+// @Description Sends code to email or phone of current account, previous code of the channel becomes void.
+// @Description New code may be requested after cooldown, see resend_at.
+// @Tags Verification
+// @Produce json
+// @Security ApiKeyAuth
+// @Param channel path string true "Contact channel" Enums(email, phone)
+// @Param Accept-Language header string false "Language of message and validation errors" Enums(en, ru)
+// @Success 200 {object} dtos.VerificationSentResponse
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 404 {object} dtos.Response
+// @Failure 409 {object} dtos.Response "Contact is not set or already verified"
+// @Failure 429 {object} dtos.Response "Code was sent recently, see Retry-After"
+// @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
+// @Router /api/v1/account/verification/{channel}/send [post]
+func (a *VerificationRouter) SendVerificationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userId, err := uuid.Parse(ctx.Value("user_id").(string))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "unable to parse uuid from user_id")
+		return
+	}
+
+	request := dtos.SendVerificationRequest{
+		UserId:   userId,
+		Channel:  chi.URLParam(r, "channel"),
+		Language: requestLanguage(r),
+	}
+
+	v, err := a.usecase.Send(ctx, request)
+	if err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, dtos.NewVerificationSentResponse(*v, a.usecase.ResendCooldown()))
+}
+
+// @Title ConfirmVerification
+// @Summary Confirm verification code
+// @Description Marks email or phone of current account verified if code matches the last one sent.
+// @Description Code is void after expiration or too many wrong attempts, new one must be requested then.
+// @Tags Verification
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param channel path string true "Contact channel" Enums(email, phone)
+// @Param request body dtos.ConfirmVerificationRequest true "Code"
+// @Param Accept-Language header string false "Language of validation errors" Enums(en, ru)
+// @Success 200 {object} dtos.Response
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Invalid code, validation errors are localized"
+// @Failure 404 {object} dtos.Response
+// @Failure 409 {object} dtos.Response "Contact is verified by another account"
+// @Failure 410 {object} dtos.Response "Code expired, exhausted attempts or was not requested"
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Failure 503 {object} dtos.Response
+// @Failure 504 {object} dtos.Response
+// @Router /api/v1/account/verification/{channel}/confirm [post]
+func (a *VerificationRouter) ConfirmVerificationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
+
+	var request dtos.ConfirmVerificationRequest
+
+	err := render.DecodeJSON(r.Body, &request)
+	if err != nil {
+		// EOF means there is no data in the request body
+		if errors.Is(err, io.EOF) {
+			response.JSON(w, http.StatusBadRequest, "request body is empty")
+			return
+		}
+
+		logger.Error("failed to decode request body", slogerr.Error(err))
+		response.JSON(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	request.UserId, err = uuid.Parse(ctx.Value("user_id").(string))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "unable to parse uuid from user_id")
+		return
+	}
+	request.Channel = chi.URLParam(r, "channel")
+
+	if err = a.usecase.Confirm(ctx, request); err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, "contact verified")
+}
+
+// error maps usecase error to response
+func (a *VerificationRouter) error(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		validationErrors validator.ValidationErrors
+		cooldown         *domain.ResendCooldownError
+	)
+
+	switch {
+	case errors.As(err, &validationErrors):
+		validationFailed(w, r, validationErrors)
+	case errors.As(err, &cooldown):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(cooldown.RetryAfter.Seconds()))))
+		response.JSON(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, domain.ErrInvalidCode):
+		response.JSON(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrAccountNotFound):
+		response.JSON(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrContactNotSet), errors.Is(err, domain.ErrContactVerified),
+		errors.Is(err, domain.ErrContactTaken):
+		response.JSON(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrVerificationNotFound), errors.Is(err, domain.ErrCodeExpired),
+		errors.Is(err, domain.ErrTooManyAttempts):
+		response.JSON(w, http.StatusGone, err.Error())
+	default:
+		if status, msg, ok := middleware.DeadlineStatus(r.Context()); ok {
+			response.JSON(w, status, msg)
+			return
+		}
+		response.JSON(w, http.StatusInternalServerError, err.Error())
+	}
+}
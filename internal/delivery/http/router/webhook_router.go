@@ -0,0 +1,296 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/delivery/http/middleware"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	response "github.com/WebChads/AccountService/internal/pkg/api"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+	"github.com/WebChads/AccountService/pkg/middleware/auth"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+type WebhookUsecase interface {
+	CreateSubscription(ctx context.Context, req dtos.CreateWebhookRequest) (*dtos.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]dtos.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, req dtos.ListWebhookDeliveriesRequest) ([]dtos.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, id int64) (*dtos.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, id int64) error
+}
+
+type WebhookRouter struct {
+	defaultHandler *chi.Mux
+	logger         *slog.Logger
+	config         *config.ServerConfig
+	usecase        WebhookUsecase
+	limiter        ratelimit.Limiter
+}
+
+func NewWebhookRouter(r *chi.Mux, cfg *config.ServerConfig,
+	log *slog.Logger, usecase WebhookUsecase, limiter ratelimit.Limiter) *WebhookRouter {
+	router := &WebhookRouter{
+		defaultHandler: r,
+		logger:         log,
+		config:         cfg,
+		usecase:        usecase,
+		limiter:        limiter,
+	}
+
+	return router
+}
+
+func ConfigureWebhookRouter(r *WebhookRouter) {
+	// Auth middleware
+	authMiddleware := auth.NewMiddleware(r.config.AuthServiceUrl)
+	userLogger := middleware.UserLogger(r.logger)
+	adminOnly := middleware.RequireRole(roleAdmin)
+
+	r.defaultHandler.Route("/api/v1/admin/webhooks", func(rout chi.Router) {
+		rout.Use(middleware.Timeout(time.Duration(r.config.Timeouts.Handler)))
+		// Callers are limited before auth
+		rout.Use(middleware.ClientRateLimit(r.config.RateLimit, r.limiter, r.logger))
+		rout.Use(authMiddleware.Handler, userLogger, adminOnly)
+
+		rout.Post("/", r.CreateWebhookHandler)
+		rout.Get("/", r.ListWebhooksHandler)
+		rout.Delete("/{webhook_id}", r.DeleteWebhookHandler)
+		rout.Get("/deliveries", r.ListDeliveriesHandler)
+		rout.Get("/deliveries/{delivery_id}", r.GetDeliveryHandler)
+		rout.Post("/deliveries/{delivery_id}/replay", r.ReplayDeliveryHandler)
+	})
+}
+
+// @Title CreateWebhook
+// @Summary Register webhook
+// @Description Subscribes URL to account events. Deliveries are signed with HMAC-SHA256 of
+// @Description "<X-Webhook-Timestamp>.<body>" using secret, sent in X-Webhook-Signature as "sha256=<hex>".
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body dtos.CreateWebhookRequest true "Webhook data"
+// @Param Accept-Language header string false "Language of validation messages" Enums(en, ru)
+// @Success 201 {object} dtos.WebhookSubscription
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/admin/webhooks [post]
+func (a *WebhookRouter) CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	logger := slogerr.FromContext(ctx, a.logger)
+
+	var request dtos.CreateWebhookRequest
+
+	err := render.DecodeJSON(r.Body, &request)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			response.JSON(w, http.StatusBadRequest, "request body is empty")
+			return
+		}
+
+		logger.Error("failed to decode request body", slogerr.Error(err))
+		response.JSON(w, http.StatusBadRequest, "failed to decode request body")
+		return
+	}
+
+	subscription, err := a.usecase.CreateSubscription(ctx, request)
+	if err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, subscription)
+}
+
+// @Title ListWebhooks
+// @Summary List webhooks
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} dtos.WebhookSubscription
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/admin/webhooks [get]
+func (a *WebhookRouter) ListWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := a.usecase.ListSubscriptions(r.Context())
+	if err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, subscriptions)
+}
+
+// @Title DeleteWebhook
+// @Summary Delete webhook
+// @Description Deletes webhook together with its delivery log
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param webhook_id path string true "Webhook ID"
+// @Success 200 {object} dtos.Response
+// @Failure 400 {object} dtos.Response
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 404 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/admin/webhooks/{webhook_id} [delete]
+func (a *WebhookRouter) DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid webhook_id")
+		return
+	}
+
+	if err := a.usecase.DeleteSubscription(r.Context(), id); err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, "webhook deleted")
+}
+
+// @Title ListWebhookDeliveries
+// @Summary Query webhook delivery log
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param subscription_id query string false "Webhook ID"
+// @Param status query string false "Delivery status" Enums(pending, succeeded, failed)
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset"
+// @Param Accept-Language header string false "Language of validation messages" Enums(en, ru)
+// @Success 200 {array} dtos.WebhookDelivery
+// @Failure 400 {object} dtos.Response{message=dtos.ValidationErrors} "Validation errors are localized"
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/admin/webhooks/deliveries [get]
+func (a *WebhookRouter) ListDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var (
+		request dtos.ListWebhookDeliveriesRequest
+		err     error
+	)
+
+	if value := query.Get("subscription_id"); value != "" {
+		request.SubscriptionId, err = uuid.Parse(value)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, "invalid subscription_id")
+			return
+		}
+	}
+
+	request.Status = query.Get("status")
+
+	if request.Limit, err = intQueryParam(query.Get("limit")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if request.Offset, err = intQueryParam(query.Get("offset")); err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	deliveries, err := a.usecase.ListDeliveries(r.Context(), request)
+	if err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, deliveries)
+}
+
+// @Title GetWebhookDelivery
+// @Summary Get webhook delivery with attempt log
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param delivery_id path int true "Delivery ID"
+// @Success 200 {object} dtos.WebhookDelivery
+// @Failure 400 {object} dtos.Response
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 404 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/admin/webhooks/deliveries/{delivery_id} [get]
+func (a *WebhookRouter) GetDeliveryHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "delivery_id"), 10, 64)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid delivery_id")
+		return
+	}
+
+	delivery, err := a.usecase.GetDelivery(r.Context(), id)
+	if err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, delivery)
+}
+
+// @Title ReplayWebhookDelivery
+// @Summary Replay webhook delivery
+// @Description Schedules delivery for immediate resend with fresh retry budget
+// @Tags Webhooks
+// @Produce json
+// @Security ApiKeyAuth
+// @Param delivery_id path int true "Delivery ID"
+// @Success 202 {object} dtos.Response
+// @Failure 400 {object} dtos.Response
+// @Failure 403 {object} dtos.Response
+// @Failure 429 {object} dtos.Response
+// @Failure 404 {object} dtos.Response
+// @Failure 500 {object} dtos.Response
+// @Router /api/v1/admin/webhooks/deliveries/{delivery_id}/replay [post]
+func (a *WebhookRouter) ReplayDeliveryHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "delivery_id"), 10, 64)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, "invalid delivery_id")
+		return
+	}
+
+	if err := a.usecase.ReplayDelivery(r.Context(), id); err != nil {
+		a.error(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusAccepted, "delivery scheduled")
+}
+
+// error maps usecase error to response
+func (a *WebhookRouter) error(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErrors validator.ValidationErrors
+
+	switch {
+	case errors.As(err, &validationErrors):
+		validationFailed(w, r, validationErrors)
+	case errors.Is(err, domain.ErrWebhookNotFound), errors.Is(err, domain.ErrDeliveryNotFound):
+		response.JSON(w, http.StatusNotFound, err.Error())
+	default:
+		if status, msg, ok := middleware.DeadlineStatus(r.Context()); ok {
+			response.JSON(w, status, msg)
+			return
+		}
+		response.JSON(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -3,11 +3,20 @@ package server
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
+	"time"
 
+	"github.com/WebChads/AccountService/internal/blob"
 	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/delivery/http/middleware"
 	"github.com/WebChads/AccountService/internal/delivery/http/router"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/pkg/ratelimit"
+	"github.com/WebChads/AccountService/internal/pkg/tracing"
+	"github.com/WebChads/AccountService/internal/stream"
 	"github.com/WebChads/AccountService/internal/usecase"
+	"github.com/WebChads/AccountService/internal/verification"
 	"github.com/go-chi/chi"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -19,13 +28,22 @@ type Server struct {
 }
 
 // Server constructor
-func NewServer(handler http.Handler, address string) *Server {
+func NewServer(handler http.Handler, cfg *config.ServerConfig) *Server {
 	srv := &http.Server{
-		Addr:           address,
-		Handler:        handler,
-		MaxHeaderBytes: 1 << 20,
+		Addr:              cfg.Address,
+		Handler:           handler,
+		MaxHeaderBytes:    1 << 20,
+		ReadTimeout:       time.Duration(cfg.Timeouts.Read),
+		ReadHeaderTimeout: time.Duration(cfg.Timeouts.ReadHeader),
+		WriteTimeout:      time.Duration(cfg.Timeouts.Write),
+		IdleTimeout:       time.Duration(cfg.Timeouts.Idle),
 	}
 
+	// Requests context is canceled on shutdown, so long-lived streams end
+	baseCtx, cancel := context.WithCancel(context.Background())
+	srv.BaseContext = func(net.Listener) context.Context { return baseCtx }
+	srv.RegisterOnShutdown(cancel)
+
 	return &Server{server: srv}
 }
 
@@ -42,19 +60,54 @@ func NewDB(ctx context.Context, connString string) (*sqlx.DB, error) {
 	return db, nil
 }
 
-func InitRouter(config *config.ServerConfig, logger *slog.Logger, db *sqlx.DB) http.Handler {
+func InitRouter(config *config.ServerConfig, logger *slog.Logger, db *sqlx.DB,
+	broker *stream.Broker, sender verification.Sender, avatars blob.BlobStore, genders domain.Genders) http.Handler {
 	rout := chi.NewRouter()
 	http.Handle("/", rout)
 
+	rout.Use(tracing.RouteMiddleware)
+	rout.Use(middleware.RequestID(logger))
+	rout.Use(middleware.AccessLog(config.AccessLog, logger))
+
 	repos := usecase.NewRepositories(db)
+	limiter := ratelimit.NewMemoryLimiter(10 * time.Minute)
 
 	// Add all routers here
-	accountUsecase := usecase.NewAccountUsecase(repos.Account, logger)
-	accountRouter := router.NewAccountRouter(rout, config, logger, accountUsecase)
+	accountUsecase := usecase.NewAccountUsecase(repos.Account, avatars, genders, logger)
+	accountRouter := router.NewAccountRouter(rout, config, logger, accountUsecase, limiter)
 	// ...
 
+	adminRouter := router.NewAdminRouter(rout, config, logger, accountUsecase, limiter)
+
+	verificationUsecase := usecase.NewVerificationUsecase(repos.Account, repos.Verification, sender,
+		verification.NewHasher(config.Verification.Secret), logger, usecase.VerificationOptions{
+			CodeLength:     config.Verification.CodeLength,
+			CodeTTL:        time.Duration(config.Verification.CodeTTL),
+			ResendCooldown: time.Duration(config.Verification.ResendCooldown),
+			MaxAttempts:    config.Verification.MaxAttempts,
+		})
+	verificationRouter := router.NewVerificationRouter(rout, config, logger, verificationUsecase, limiter)
+
+	avatarUsecase := usecase.NewAvatarUsecase(repos.Account, avatars, logger, usecase.AvatarOptions{
+		MaxSize:   config.Avatars.MaxSize,
+		MaxPixels: config.Avatars.MaxPixels,
+		Sizes:     config.Avatars.Sizes,
+		BaseURL:   config.Avatars.BaseURL,
+	})
+	avatarRouter := router.NewAvatarRouter(rout, config, logger, avatarUsecase, limiter)
+
+	webhookUsecase := usecase.NewWebhookUsecase(repos.Webhook, logger)
+	webhookRouter := router.NewWebhookRouter(rout, config, logger, webhookUsecase, limiter)
+
+	streamRouter := router.NewStreamRouter(rout, config, logger, broker, limiter)
+
 	// Configure routers
 	router.ConfigureAccountRouter(accountRouter)
+	router.ConfigureAdminRouter(adminRouter)
+	router.ConfigureVerificationRouter(verificationRouter)
+	router.ConfigureAvatarRouter(avatarRouter)
+	router.ConfigureWebhookRouter(webhookRouter)
+	router.ConfigureStreamRouter(streamRouter)
 	// ...
 
 	// Serve Swagger UI
@@ -62,7 +115,7 @@ func InitRouter(config *config.ServerConfig, logger *slog.Logger, db *sqlx.DB) h
 		httpSwagger.URL("/swagger/doc.json"),
 	))
 
-	return rout
+	return tracing.Handler(rout)
 }
 
 func (s *Server) ListenAndServe() error {
@@ -0,0 +1,281 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxAge is the oldest plausible age of account holder
+const MaxAge = 150
+
+var (
+	ErrInvalidUserId    = errors.New("user id is required")
+	ErrEmptyName        = errors.New("firstname and surname must not be empty")
+	ErrInvalidGender    = errors.New("gender must be one of allowed gender codes")
+	ErrInvalidBirthdate = errors.New("birthdate must not be in the future or older than max age")
+	ErrNoBirthdate      = errors.New("birthdate is required")
+	ErrAccountExists    = errors.New("account with this id already exists")
+	ErrAccountNotFound  = errors.New("no account with such id")
+)
+
+// Account is user profile
+type Account struct {
+	UserId     uuid.UUID
+	Firstname  string
+	Surname    string
+	Patronymic string
+	// Gender is canonical gender code, see GenderMale and others
+	Gender string
+	// Birthdate is calendar date at midnight UTC, zero if unknown,
+	// e.g. for account provisioned from registration event
+	Birthdate time.Time
+	// Profile is optional info, its fields are promoted
+	Profile
+	Contacts
+	// Avatar is nil until picture is uploaded
+	Avatar *Avatar
+	// CreatedAt and UpdatedAt are set by storage
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewAccount creates account with normalized names and checks its invariants
+func NewAccount(userId uuid.UUID, firstname, surname, patronymic, gender string,
+	birthdate time.Time, profile Profile, contacts Contacts) (Account, error) {
+	// New contacts are not verified yet
+	contacts.Email.VerifiedAt = nil
+	contacts.Phone.VerifiedAt = nil
+
+	account := Account{
+		UserId:     userId,
+		Firstname:  NormalizeName(firstname),
+		Surname:    NormalizeName(surname),
+		Patronymic: NormalizeName(patronymic),
+		Gender:     gender,
+		Birthdate:  dateOf(birthdate),
+		Profile:    profile.Normalized(),
+		Contacts:   contacts.Normalized(),
+	}
+
+	if err := account.Validate(); err != nil {
+		return Account{}, err
+	}
+
+	return account, nil
+}
+
+// Validate checks invariants every stored account holds
+func (a Account) Validate() error {
+	if a.UserId == uuid.Nil {
+		return ErrInvalidUserId
+	}
+	if err := validateName(a.Firstname); err != nil {
+		return err
+	}
+	if err := validateName(a.Surname); err != nil {
+		return err
+	}
+	if err := validateGender(a.Gender); err != nil {
+		return err
+	}
+	if err := validateBirthdate(a.Birthdate); err != nil {
+		return err
+	}
+
+	if err := a.Profile.Validate(); err != nil {
+		return err
+	}
+
+	return a.Contacts.Validate()
+}
+
+// Location returns account time zone, UTC if it is not set or unknown
+func (a Account) Location() *time.Location {
+	if a.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(a.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// HasBirthdate reports whether birthdate is known
+func (a Account) HasBirthdate() bool {
+	return !a.Birthdate.IsZero()
+}
+
+// Age returns full years passed since birthdate at now in account time zone,
+// birthdate must be known
+func (a Account) Age(now time.Time) int {
+	return a.AgeOn(dateOf(now.In(a.Location())))
+}
+
+// AgeOn returns full years passed since birthdate on calendar date
+func (a Account) AgeOn(date time.Time) int {
+	age := date.Year() - a.Birthdate.Year()
+	if dateOf(date).Before(a.BirthdayIn(date.Year())) {
+		age--
+	}
+
+	return age
+}
+
+// FullName returns name in "Surname Firstname Patronymic" order
+func (a Account) FullName() string {
+	return joinNonEmpty(a.Surname, a.Firstname, a.Patronymic)
+}
+
+// ShortName returns surname with initials, e.g. "Иванов И. И."
+func (a Account) ShortName() string {
+	return joinNonEmpty(a.Surname, initial(a.Firstname), initial(a.Patronymic))
+}
+
+// AccountChanges is partial account update, nil fields are not changed
+type AccountChanges struct {
+	UserId     uuid.UUID
+	Firstname  *string
+	Surname    *string
+	Patronymic *string
+	Gender     *string
+	Birthdate  *time.Time
+	ProfileChanges
+	ContactChanges
+}
+
+// Normalized returns changes with normalized names, profile and contacts, see NormalizeName
+func (c AccountChanges) Normalized() AccountChanges {
+	normalize := func(name *string) *string {
+		if name == nil {
+			return nil
+		}
+		normalized := NormalizeName(*name)
+		return &normalized
+	}
+
+	c.Firstname = normalize(c.Firstname)
+	c.Surname = normalize(c.Surname)
+	c.Patronymic = normalize(c.Patronymic)
+	c.ProfileChanges = c.ProfileChanges.Normalized()
+	c.ContactChanges = c.ContactChanges.Normalized()
+
+	return c
+}
+
+func (c AccountChanges) IsEmpty() bool {
+	return c.Firstname == nil && c.Surname == nil && c.Patronymic == nil &&
+		c.Gender == nil && c.Birthdate == nil && c.ProfileChanges.IsEmpty() && c.ContactChanges.IsEmpty()
+}
+
+// Validate checks that changed fields keep account invariants
+func (c AccountChanges) Validate() error {
+	if c.UserId == uuid.Nil {
+		return ErrInvalidUserId
+	}
+	if c.Firstname != nil {
+		if err := validateName(*c.Firstname); err != nil {
+			return err
+		}
+	}
+	if c.Surname != nil {
+		if err := validateName(*c.Surname); err != nil {
+			return err
+		}
+	}
+	if c.Gender != nil {
+		if err := validateGender(*c.Gender); err != nil {
+			return err
+		}
+	}
+	if c.Birthdate != nil {
+		if err := validateBirthdate(*c.Birthdate); err != nil {
+			return err
+		}
+	}
+
+	if err := c.ProfileChanges.Validate(); err != nil {
+		return err
+	}
+
+	return c.ContactChanges.Validate()
+}
+
+// AccountFilter selects accounts, zero fields are not applied
+type AccountFilter struct {
+	// Surname is matched by prefix and Firstname exactly, both
+	// case-insensitively in either Cyrillic or Latin script, see NameKey
+	Surname     string
+	Firstname   string
+	Gender      string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Limit       int
+	Offset      int
+}
+
+// AccountMatch is account found by fuzzy search
+type AccountMatch struct {
+	Account Account
+	// Rank is relevance of match, higher is better
+	Rank float64
+}
+
+func validateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrEmptyName
+	}
+	return nil
+}
+
+// validateGender accepts canonical codes only, aliases are resolved by Genders.Parse
+func validateGender(gender string) error {
+	if !IsGenderCode(gender) {
+		return ErrInvalidGender
+	}
+	return nil
+}
+
+// validateBirthdate rejects dates in the future and implausible ages, zero birthdate is unknown
+func validateBirthdate(birthdate time.Time) error {
+	if birthdate.IsZero() {
+		return nil
+	}
+
+	today := dateOf(time.Now())
+	if birthdate.After(today) || birthdate.Before(today.AddDate(-MaxAge, 0, 0)) {
+		return ErrInvalidBirthdate
+	}
+	return nil
+}
+
+// dateOf truncates t to calendar date at midnight UTC
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func initial(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	first, _ := utf8.DecodeRuneInString(name)
+	return string(first) + "."
+}
+
+func joinNonEmpty(parts ...string) string {
+	nonEmpty := parts[:0:0]
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+
+	return strings.Join(nonEmpty, " ")
+}
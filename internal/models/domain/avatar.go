@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAvatarTooLarge = errors.New("avatar file is too large")
+	ErrAvatarNotSet   = errors.New("avatar is not set")
+)
+
+// Avatar is account picture stored as square thumbnails.
+// Every upload has new id, so URLs of previous avatar are never reused.
+type Avatar struct {
+	ID uuid.UUID
+	// Keys are blob keys of thumbnails, they are removed with avatar
+	Keys []string
+	// URLs are thumbnail URLs by side in pixels, e.g. "128"
+	URLs       map[string]string
+	UploadedAt time.Time
+}
+
+// AvatarKey returns blob key of avatar thumbnail, e.g. "<user id>/<avatar id>/128.jpg"
+func AvatarKey(userId, avatarId uuid.UUID, size int, ext string) string {
+	return userId.String() + "/" + avatarId.String() + "/" + strconv.Itoa(size) + ext
+}
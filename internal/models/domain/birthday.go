@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxBirthdayRange is the longest range of birthday query, longer range matches every account
+const MaxBirthdayRange = 366
+
+var ErrInvalidBirthdayRange = errors.New("birthday range must not be reversed or longer than a year")
+
+// Birthday is account birthday falling in queried range
+type Birthday struct {
+	Account Account
+	// Date is the day birthday is celebrated on, at midnight UTC
+	Date time.Time
+	// Age is the age account holder turns on Date
+	Age int
+}
+
+// BirthdayIn returns the day birthday is celebrated on in year.
+// Those born on February 29 become a year older on March 1 in non-leap years.
+func (a Account) BirthdayIn(year int) time.Time {
+	month, day := a.Birthdate.Month(), a.Birthdate.Day()
+	if month == time.February && day == 29 && !isLeap(year) {
+		month, day = time.March, 1
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// NextBirthday returns the first birthday on or after calendar date
+func (a Account) NextBirthday(date time.Time) time.Time {
+	date = dateOf(date)
+
+	birthday := a.BirthdayIn(date.Year())
+	if birthday.Before(date) {
+		birthday = a.BirthdayIn(date.Year() + 1)
+	}
+
+	return birthday
+}
+
+// BirthdayKey identifies birthdate by month and day, e.g. 1231 for December 31
+func BirthdayKey(month time.Month, day int) int {
+	return int(month)*100 + day
+}
+
+// BirthdayKeys returns keys of birthdates celebrated on days from..to inclusive,
+// in order of celebration. February 29 is placed on March 1 of non-leap years.
+func BirthdayKeys(from, to time.Time) ([]int, error) {
+	from, to = dateOf(from), dateOf(to)
+	if to.Before(from) || to.Sub(from) >= MaxBirthdayRange*24*time.Hour {
+		return nil, ErrInvalidBirthdayRange
+	}
+
+	var keys []int
+	seen := map[int]bool{}
+	add := func(key int) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if day.Month() == time.March && day.Day() == 1 && !isLeap(day.Year()) {
+			add(BirthdayKey(time.February, 29))
+		}
+		add(BirthdayKey(day.Month(), day.Day()))
+	}
+
+	return keys, nil
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBirthdayIn(t *testing.T) {
+	leapling := Account{Birthdate: date(2000, time.February, 29)}
+
+	tests := []struct {
+		year int
+		want time.Time
+	}{
+		{2024, date(2024, time.February, 29)},
+		{2023, date(2023, time.March, 1)},
+		{1900, date(1900, time.March, 1)},
+		{2000, date(2000, time.February, 29)},
+	}
+
+	for _, tt := range tests {
+		if got := leapling.BirthdayIn(tt.year); !got.Equal(tt.want) {
+			t.Errorf("BirthdayIn(%d) = %v, want %v", tt.year, got, tt.want)
+		}
+	}
+
+	regular := Account{Birthdate: date(1990, time.February, 28)}
+	if got := regular.BirthdayIn(2023); !got.Equal(date(2023, time.February, 28)) {
+		t.Errorf("BirthdayIn(2023) = %v, want February 28", got)
+	}
+}
+
+func TestNextBirthday(t *testing.T) {
+	a := Account{Birthdate: date(2000, time.February, 29)}
+
+	tests := []struct {
+		on   time.Time
+		want time.Time
+	}{
+		{date(2023, time.January, 10), date(2023, time.March, 1)},
+		{date(2023, time.March, 1), date(2023, time.March, 1)},
+		{date(2023, time.March, 2), date(2024, time.February, 29)},
+	}
+
+	for _, tt := range tests {
+		if got := a.NextBirthday(tt.on); !got.Equal(tt.want) {
+			t.Errorf("NextBirthday(%v) = %v, want %v", tt.on, got, tt.want)
+		}
+	}
+}
+
+func TestBirthdayKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []int
+	}{
+		{
+			name: "single day",
+			from: date(2024, time.May, 10),
+			to:   date(2024, time.May, 10),
+			want: []int{510},
+		},
+		{
+			name: "wraps over new year",
+			from: date(2023, time.December, 30),
+			to:   date(2024, time.January, 2),
+			want: []int{1230, 1231, 101, 102},
+		},
+		{
+			name: "leap day in leap year",
+			from: date(2024, time.February, 28),
+			to:   date(2024, time.March, 1),
+			want: []int{228, 229, 301},
+		},
+		{
+			name: "leap day on March 1 of non-leap year",
+			from: date(2023, time.February, 28),
+			to:   date(2023, time.March, 1),
+			want: []int{228, 229, 301},
+		},
+		{
+			name: "time of day is ignored",
+			from: time.Date(2024, time.May, 10, 23, 59, 0, 0, time.UTC),
+			to:   time.Date(2024, time.May, 11, 0, 1, 0, 0, time.UTC),
+			want: []int{510, 511},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BirthdayKeys(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("BirthdayKeys() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("BirthdayKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBirthdayKeysRange(t *testing.T) {
+	from := date(2024, time.January, 1)
+
+	keys, err := BirthdayKeys(from, from.AddDate(0, 0, MaxBirthdayRange-1))
+	if err != nil {
+		t.Fatalf("BirthdayKeys() for %d days error = %v", MaxBirthdayRange, err)
+	}
+	if len(keys) != 366 {
+		t.Errorf("BirthdayKeys() for a leap year returned %d keys, want 366", len(keys))
+	}
+
+	if _, err := BirthdayKeys(from, from.AddDate(0, 0, MaxBirthdayRange)); !errors.Is(err, ErrInvalidBirthdayRange) {
+		t.Errorf("BirthdayKeys() for %d days error = %v, want %v", MaxBirthdayRange+1, err, ErrInvalidBirthdayRange)
+	}
+
+	if _, err := BirthdayKeys(from, from.AddDate(0, 0, -1)); !errors.Is(err, ErrInvalidBirthdayRange) {
+		t.Errorf("BirthdayKeys() for reversed range error = %v, want %v", err, ErrInvalidBirthdayRange)
+	}
+}
+
+func TestAgeOn(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	a := Account{Birthdate: date(1990, time.May, 10)}
+
+	tests := []struct {
+		name string
+		on   time.Time
+		want int
+	}{
+		{"day before birthday", date(2024, time.May, 9), 33},
+		{"on birthday", date(2024, time.May, 10), 34},
+		// 00:30 in Moscow is still May 9 in UTC, age follows the local date
+		{"local midnight", time.Date(2024, time.May, 10, 0, 30, 0, 0, moscow), 34},
+		{"local day before", time.Date(2024, time.May, 9, 23, 30, 0, 0, moscow), 33},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a.AgeOn(tt.on); got != tt.want {
+				t.Errorf("AgeOn(%v) = %d, want %d", tt.on, got, tt.want)
+			}
+		})
+	}
+
+	leapling := Account{Birthdate: date(2000, time.February, 29)}
+	if got := leapling.AgeOn(date(2023, time.February, 28)); got != 22 {
+		t.Errorf("AgeOn(2023-02-28) = %d, want 22", got)
+	}
+	if got := leapling.AgeOn(date(2023, time.March, 1)); got != 23 {
+		t.Errorf("AgeOn(2023-03-01) = %d, want 23", got)
+	}
+}
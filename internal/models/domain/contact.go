@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Contact channels, codes are verified per channel
+const (
+	ChannelEmail = "email"
+	ChannelPhone = "phone"
+)
+
+// MaxEmailLength is limit of RFC 5321 path
+const MaxEmailLength = 254
+
+var (
+	ErrInvalidEmail   = errors.New("email must be valid address")
+	ErrInvalidPhone   = errors.New("phone must be international number, e.g. +79991234567")
+	ErrUnknownChannel = errors.New("channel must be email or phone")
+)
+
+// Contact is email or phone with its verification status
+type Contact struct {
+	Value string
+	// VerifiedAt is set once code sent to Value is confirmed
+	VerifiedAt *time.Time
+}
+
+func (c Contact) Verified() bool {
+	return c.Value != "" && c.VerifiedAt != nil
+}
+
+// Contacts are optional account contacts, changed value loses verification
+type Contacts struct {
+	Email Contact
+	Phone Contact
+}
+
+// Contact returns contact of channel
+func (c Contacts) Contact(channel string) (Contact, error) {
+	switch channel {
+	case ChannelEmail:
+		return c.Email, nil
+	case ChannelPhone:
+		return c.Phone, nil
+	}
+
+	return Contact{}, ErrUnknownChannel
+}
+
+// Normalized returns contacts with canonical values, see NormalizeEmail and NormalizePhone
+func (c Contacts) Normalized() Contacts {
+	c.Email.Value = NormalizeEmail(c.Email.Value)
+	c.Phone.Value = NormalizePhone(c.Phone.Value)
+
+	return c
+}
+
+func (c Contacts) Validate() error {
+	if err := validateEmail(c.Email.Value); err != nil {
+		return err
+	}
+
+	return validatePhone(c.Phone.Value)
+}
+
+// ContactChanges is partial contacts update, nil fields are not changed
+// and empty strings unset fields
+type ContactChanges struct {
+	Email *string
+	Phone *string
+}
+
+func (c ContactChanges) IsEmpty() bool {
+	return c.Email == nil && c.Phone == nil
+}
+
+// Normalized returns changes normalized as in Contacts.Normalized
+func (c ContactChanges) Normalized() ContactChanges {
+	if c.Email != nil {
+		email := NormalizeEmail(*c.Email)
+		c.Email = &email
+	}
+	if c.Phone != nil {
+		phone := NormalizePhone(*c.Phone)
+		c.Phone = &phone
+	}
+
+	return c
+}
+
+func (c ContactChanges) Validate() error {
+	if c.Email != nil {
+		if err := validateEmail(*c.Email); err != nil {
+			return err
+		}
+	}
+	if c.Phone != nil {
+		return validatePhone(*c.Phone)
+	}
+
+	return nil
+}
+
+// IsChannel reports whether channel is known contact channel
+func IsChannel(channel string) bool {
+	return channel == ChannelEmail || channel == ChannelPhone
+}
+
+// NormalizeEmail trims and lowercases email, so the same address has one form
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone removes formatting from phone number, e.g. "+79991234567"
+// for "+7 (999) 123-45-67", invalid numbers are kept for Validate to reject
+func NormalizePhone(phone string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')', '.':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(phone))
+}
+
+// IsEmail reports whether email is bare address without display name
+func IsEmail(email string) bool {
+	if len(email) > MaxEmailLength {
+		return false
+	}
+
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
+
+// IsPhone reports whether phone is E.164 number: "+", country code and up to 15 digits
+func IsPhone(phone string) bool {
+	if len(phone) < 8 || len(phone) > 16 || phone[0] != '+' || phone[1] == '0' {
+		return false
+	}
+
+	for _, r := range phone[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateEmail(email string) error {
+	if email != "" && !IsEmail(email) {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+func validatePhone(phone string) error {
+	if phone != "" && !IsPhone(phone) {
+		return ErrInvalidPhone
+	}
+	return nil
+}
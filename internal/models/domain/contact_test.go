@@ -0,0 +1,43 @@
+package domain
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := map[string]string{
+		"+7 (999) 123-45-67": "+79991234567",
+		" +44.20.7946.0958 ": "+442079460958",
+		"+79991234567":       "+79991234567",
+		// Invalid numbers are kept for IsPhone to reject
+		"8 999 abc": "8999abc",
+	}
+
+	for phone, want := range tests {
+		if got := NormalizePhone(phone); got != want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", phone, got, want)
+		}
+	}
+}
+
+func TestIsPhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  bool
+	}{
+		{"+79991234567", true},
+		{"+1234567", true},
+		{"+123456789012345", true},
+		{"+1234567890123456", false},
+		{"+123456", false},
+		{"79991234567", false},
+		{"+09991234567", false},
+		{"+7999123456a", false},
+		{"+7 999 123 45 67", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPhone(tt.phone); got != tt.want {
+			t.Errorf("IsPhone(%q) = %t, want %t", tt.phone, got, tt.want)
+		}
+	}
+}
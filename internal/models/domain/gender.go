@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"errors"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Built-in gender codes, config may add more to catalog
+const (
+	GenderMale        = "M"
+	GenderFemale      = "F"
+	GenderNonBinary   = "N"
+	GenderUndisclosed = "U"
+)
+
+// DefaultLanguage is language of labels used when requested one is missing
+const DefaultLanguage = "en"
+
+// genderCodePattern matches accounts_gender_check constraint of migration 000013
+var genderCodePattern = regexp.MustCompile(`^[A-Z]$`)
+
+// Gender is canonical gender value
+type Gender struct {
+	Code string
+	// Labels are display names by language
+	Labels map[string]string
+	// aliases are accepted on input in addition to code, lower case
+	aliases []string
+}
+
+// NewGender creates catalog entry, aliases are matched case-insensitively
+func NewGender(code string, labels map[string]string, aliases []string) Gender {
+	g := Gender{Code: code, Labels: maps.Clone(labels)}
+	for _, alias := range aliases {
+		g.aliases = append(g.aliases, strings.ToLower(strings.TrimSpace(alias)))
+	}
+
+	return g
+}
+
+// builtinGenders are genders every catalog has, in display order
+var builtinGenders = Genders{
+	{
+		Code:    GenderMale,
+		Labels:  map[string]string{"en": "Male", "ru": "Мужской"},
+		aliases: []string{"male", "man", "м", "муж", "мужской"},
+	},
+	{
+		Code:    GenderFemale,
+		Labels:  map[string]string{"en": "Female", "ru": "Женский"},
+		aliases: []string{"female", "woman", "ж", "жен", "женский"},
+	},
+	{
+		Code:    GenderNonBinary,
+		Labels:  map[string]string{"en": "Non-binary", "ru": "Небинарный"},
+		aliases: []string{"non-binary", "nonbinary", "x"},
+	},
+	{
+		Code:    GenderUndisclosed,
+		Labels:  map[string]string{"en": "Prefer not to say", "ru": "Не указан"},
+		aliases: []string{"undisclosed", "unknown"},
+	},
+}
+
+// Label returns display name in language, falls back to DefaultLanguage
+func (g Gender) Label(lang string) string {
+	if label, ok := g.Labels[lang]; ok {
+		return label
+	}
+
+	return g.Labels[DefaultLanguage]
+}
+
+// matches reports whether value is code or alias of gender, case is ignored
+func (g Gender) matches(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return value == strings.ToLower(g.Code) || slices.Contains(g.aliases, value)
+}
+
+// IsGenderCode reports whether code may be stored as gender.
+// Whether gender is allowed is decided by Genders of usecase.
+func IsGenderCode(code string) bool {
+	return genderCodePattern.MatchString(code)
+}
+
+// Genders is set of genders allowed in accounts
+type Genders []Gender
+
+// NewGenderCatalog returns built-in genders merged with extra ones, see Genders.Merge
+func NewGenderCatalog(extra ...Gender) (Genders, error) {
+	return builtinGenders.Merge(extra...)
+}
+
+// Merge returns genders with extra ones added. Extra gender with known code
+// overrides its labels by language and adds aliases, new code is appended
+// and must have label in DefaultLanguage.
+func (g Genders) Merge(extra ...Gender) (Genders, error) {
+	merged := make(Genders, 0, len(g)+len(extra))
+	for _, gender := range g {
+		gender.Labels = maps.Clone(gender.Labels)
+		gender.aliases = slices.Clone(gender.aliases)
+		merged = append(merged, gender)
+	}
+
+	for _, gender := range extra {
+		if !genderCodePattern.MatchString(gender.Code) {
+			return nil, errors.New("gender code " + gender.Code + " must be single latin capital letter")
+		}
+
+		i := slices.IndexFunc(merged, func(known Gender) bool { return known.Code == gender.Code })
+		if i < 0 {
+			if gender.Labels[DefaultLanguage] == "" {
+				return nil, errors.New("gender " + gender.Code + " must have " + DefaultLanguage + " label")
+			}
+			merged = append(merged, Gender{Code: gender.Code, Labels: map[string]string{}})
+			i = len(merged) - 1
+		}
+
+		maps.Copy(merged[i].Labels, gender.Labels)
+		for _, alias := range gender.aliases {
+			if j := slices.IndexFunc(merged, func(known Gender) bool { return known.matches(alias) }); j >= 0 && j != i {
+				return nil, errors.New("gender alias " + alias + " is already used by " + merged[j].Code)
+			}
+			if !slices.Contains(merged[i].aliases, alias) {
+				merged[i].aliases = append(merged[i].aliases, alias)
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// AllowedGenders returns genders of catalog with given codes in catalog order,
+// empty codes allow whole catalog
+func AllowedGenders(catalog Genders, codes []string) (Genders, error) {
+	if len(codes) == 0 {
+		return slices.Clone(catalog), nil
+	}
+
+	for _, code := range codes {
+		if !slices.Contains(catalog.Codes(), code) {
+			return nil, errors.New("unknown gender code: " + code)
+		}
+	}
+
+	var allowed Genders
+	for _, g := range catalog {
+		if slices.Contains(codes, g.Code) {
+			allowed = append(allowed, g)
+		}
+	}
+
+	return allowed, nil
+}
+
+// Parse returns canonical code of allowed gender given by code or alias
+func (g Genders) Parse(value string) (string, error) {
+	for _, gender := range g {
+		if gender.matches(value) {
+			return gender.Code, nil
+		}
+	}
+
+	return "", ErrInvalidGender
+}
+
+// Codes returns canonical codes of genders
+func (g Genders) Codes() []string {
+	codes := make([]string, 0, len(g))
+	for _, gender := range g {
+		codes = append(codes, gender.Code)
+	}
+
+	return codes
+}
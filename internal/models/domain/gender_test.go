@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewGenderCatalog(t *testing.T) {
+	catalog, err := NewGenderCatalog(
+		NewGender("O", map[string]string{"en": "Other", "ru": "Другой"}, []string{"Other"}),
+		NewGender(GenderUndisclosed, map[string]string{"en": "Unknown"}, []string{"n/a"}),
+	)
+	if err != nil {
+		t.Fatalf("NewGenderCatalog() error = %v", err)
+	}
+
+	if got, want := catalog.Codes(), []string{"M", "F", "N", "U", "O"}; !slices.Equal(got, want) {
+		t.Fatalf("Codes() = %v, want %v", got, want)
+	}
+	for value, want := range map[string]string{"other": "O", " OTHER ": "O", "n/a": "U", "unknown": "U"} {
+		if code, err := catalog.Parse(value); err != nil || code != want {
+			t.Errorf("Parse(%q) = %q, %v, want %q", value, code, err, want)
+		}
+	}
+
+	// Relabeled gender keeps labels that were not overridden
+	if label := catalog[3].Label("en"); label != "Unknown" {
+		t.Errorf("Label(en) = %q, want Unknown", label)
+	}
+	if label := catalog[3].Label("ru"); label != "Не указан" {
+		t.Errorf("Label(ru) = %q, want built-in label", label)
+	}
+
+	// Built-in genders are not changed by other catalogs
+	builtin, err := NewGenderCatalog()
+	if err != nil {
+		t.Fatalf("NewGenderCatalog() error = %v", err)
+	}
+	if label := builtin[3].Label("en"); label != "Prefer not to say" {
+		t.Errorf("built-in Label(en) = %q after relabeling other catalog", label)
+	}
+	if _, err = builtin.Parse("other"); err == nil {
+		t.Error("built-in catalog parses alias of other catalog")
+	}
+}
+
+func TestNewGenderCatalogRejectsInvalidEntries(t *testing.T) {
+	tests := map[string]Gender{
+		"long code":        NewGender("OT", map[string]string{"en": "Other"}, nil),
+		"lower case code":  NewGender("o", map[string]string{"en": "Other"}, nil),
+		"cyrillic code":    NewGender("Д", map[string]string{"en": "Other"}, nil),
+		"no default label": NewGender("O", map[string]string{"ru": "Другой"}, nil),
+		"taken alias":      NewGender("O", map[string]string{"en": "Other"}, []string{"Male"}),
+		"code as alias":    NewGender("O", map[string]string{"en": "Other"}, []string{"f"}),
+	}
+
+	for name, gender := range tests {
+		if _, err := NewGenderCatalog(gender); err == nil {
+			t.Errorf("NewGenderCatalog() with %s error = nil", name)
+		}
+	}
+}
+
+func TestAllowedGenders(t *testing.T) {
+	catalog, err := NewGenderCatalog()
+	if err != nil {
+		t.Fatalf("NewGenderCatalog() error = %v", err)
+	}
+
+	allowed, err := AllowedGenders(catalog, []string{"U", "M"})
+	if err != nil {
+		t.Fatalf("AllowedGenders() error = %v", err)
+	}
+	if got, want := allowed.Codes(), []string{"M", "U"}; !slices.Equal(got, want) {
+		t.Fatalf("Codes() = %v, want %v in catalog order", got, want)
+	}
+
+	if all, _ := AllowedGenders(catalog, nil); len(all) != len(catalog) {
+		t.Fatalf("AllowedGenders() without codes = %v, want whole catalog", all.Codes())
+	}
+	if _, err = AllowedGenders(catalog, []string{"M", "O"}); err == nil {
+		t.Fatal("AllowedGenders() with unknown code error = nil")
+	}
+}
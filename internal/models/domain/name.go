@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/WebChads/AccountService/internal/pkg/translit"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeName trims and collapses whitespace, composes runes (NFC) and
+// title-cases every word, so "  иВАН-петр " becomes "Иван-Петр"
+func NormalizeName(name string) string {
+	name = strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+
+	runes := []rune(name)
+	wordStart := true
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			wordStart = true
+			continue
+		}
+
+		if wordStart {
+			runes[i] = unicode.ToUpper(r)
+		} else {
+			runes[i] = unicode.ToLower(r)
+		}
+		wordStart = false
+	}
+
+	return string(runes)
+}
+
+// NameKey returns search key of name: lower case Latin transliteration
+// without apostrophes, so "Иванов", "ИВАНОВ" and "ivanov" have the same key
+func NameKey(name string) string {
+	name = strings.ToLower(strings.Join(strings.Fields(norm.NFC.String(name)), " "))
+
+	return strings.NewReplacer("`", "", "'", "").Replace(translit.GOST(name))
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"  иВАН-петр ":  "Иван-Петр",
+		"анна \t мария": "Анна Мария",
+		"о'коннор":      "О'Коннор",
+		"d'artagnan":    "D'Artagnan",
+		"е\u0308лкин":   "Ёлкин",
+		"людовик xiv":   "Людовик Xiv",
+		"ИВАНОВ":        "Иванов",
+		"":              "",
+	}
+
+	for in, want := range tests {
+		if got := NormalizeName(in); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNameKey(t *testing.T) {
+	tests := map[string]string{
+		"Иванов":  "ivanov",
+		"ИВАНОВ":  "ivanov",
+		"ivanov":  "ivanov",
+		"Цыганов": "cyganov",
+		"Цапкин":  "czapkin",
+		// Hard sign and apostrophes are dropped
+		"Объедков":   "obedkov",
+		"О'Коннор":   "okonnor",
+		"Д`Артаньян": "dartanyan",
+		// Decomposed input has the same key as composed one
+		"Е\u0308лкин":      "yolkin",
+		"  Анна   Мария  ": "anna mariya",
+	}
+
+	for in, want := range tests {
+		if got := NameKey(in); got != want {
+			t.Errorf("NameKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
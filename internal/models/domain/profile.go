@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Profile field limits in characters
+const (
+	MaxDisplayNameLength = 64
+	MaxBioLength         = 500
+	MaxCityLength        = 128
+)
+
+var (
+	ErrInvalidDisplayName = errors.New("display name must be at most 64 characters")
+	ErrInvalidBio         = errors.New("bio must be at most 500 characters")
+	ErrInvalidLocale      = errors.New("locale must be BCP 47 language tag")
+	ErrInvalidTimezone    = errors.New("timezone must be IANA time zone name")
+	ErrInvalidCountry     = errors.New("country must be ISO 3166-1 alpha-2 code")
+	ErrInvalidCity        = errors.New("city must be at most 128 characters")
+)
+
+// Profile is optional account info, empty fields are not set
+type Profile struct {
+	DisplayName string
+	Bio         string
+	// Locale is BCP 47 language tag, e.g. "ru-RU"
+	Locale string
+	// Timezone is IANA time zone name, empty means UTC
+	Timezone string
+	// Country is ISO 3166-1 alpha-2 code, e.g. "RU"
+	Country string
+	City    string
+}
+
+// Normalized returns profile with trimmed text and canonical codes,
+// invalid codes are kept as is for Validate to reject
+func (p Profile) Normalized() Profile {
+	p.DisplayName = collapseSpaces(p.DisplayName)
+	p.Bio = strings.TrimSpace(norm.NFC.String(p.Bio))
+	p.Locale = canonicalLocale(p.Locale)
+	p.Timezone = strings.TrimSpace(p.Timezone)
+	p.Country = strings.ToUpper(strings.TrimSpace(p.Country))
+	p.City = collapseSpaces(p.City)
+
+	return p
+}
+
+func (p Profile) Validate() error {
+	if err := validateDisplayName(p.DisplayName); err != nil {
+		return err
+	}
+	if err := validateBio(p.Bio); err != nil {
+		return err
+	}
+	if err := validateLocale(p.Locale); err != nil {
+		return err
+	}
+	if err := validateTimezone(p.Timezone); err != nil {
+		return err
+	}
+	if err := validateCountry(p.Country); err != nil {
+		return err
+	}
+
+	return validateCity(p.City)
+}
+
+// ProfileChanges is partial profile update, nil fields are not changed
+// and empty strings unset fields
+type ProfileChanges struct {
+	DisplayName *string
+	Bio         *string
+	Locale      *string
+	Timezone    *string
+	Country     *string
+	City        *string
+}
+
+func (c ProfileChanges) IsEmpty() bool {
+	return c.DisplayName == nil && c.Bio == nil && c.Locale == nil &&
+		c.Timezone == nil && c.Country == nil && c.City == nil
+}
+
+// Normalized returns changes normalized as in Profile.Normalized
+func (c ProfileChanges) Normalized() ProfileChanges {
+	var p Profile
+	get := func(field *string) string {
+		if field == nil {
+			return ""
+		}
+		return *field
+	}
+
+	p.DisplayName, p.Bio, p.Locale = get(c.DisplayName), get(c.Bio), get(c.Locale)
+	p.Timezone, p.Country, p.City = get(c.Timezone), get(c.Country), get(c.City)
+	p = p.Normalized()
+
+	set := func(field *string, value string) *string {
+		if field == nil {
+			return nil
+		}
+		return &value
+	}
+
+	return ProfileChanges{
+		DisplayName: set(c.DisplayName, p.DisplayName),
+		Bio:         set(c.Bio, p.Bio),
+		Locale:      set(c.Locale, p.Locale),
+		Timezone:    set(c.Timezone, p.Timezone),
+		Country:     set(c.Country, p.Country),
+		City:        set(c.City, p.City),
+	}
+}
+
+func (c ProfileChanges) Validate() error {
+	checks := []struct {
+		field    *string
+		validate func(string) error
+	}{
+		{c.DisplayName, validateDisplayName},
+		{c.Bio, validateBio},
+		{c.Locale, validateLocale},
+		{c.Timezone, validateTimezone},
+		{c.Country, validateCountry},
+		{c.City, validateCity},
+	}
+
+	for _, check := range checks {
+		if check.field == nil {
+			continue
+		}
+		if err := check.validate(*check.field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDisplayName(name string) error {
+	if utf8.RuneCountInString(name) > MaxDisplayNameLength {
+		return ErrInvalidDisplayName
+	}
+	return nil
+}
+
+func validateBio(bio string) error {
+	if utf8.RuneCountInString(bio) > MaxBioLength {
+		return ErrInvalidBio
+	}
+	return nil
+}
+
+func validateLocale(locale string) error {
+	if locale == "" {
+		return nil
+	}
+	if _, err := language.Parse(locale); err != nil {
+		return ErrInvalidLocale
+	}
+	return nil
+}
+
+// validateTimezone accepts empty string and IANA names, fixed "Local" zone
+// depends on server and is rejected
+func validateTimezone(timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	if timezone == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+func validateCountry(country string) error {
+	if country == "" {
+		return nil
+	}
+	if !IsCountryCode(country) {
+		return ErrInvalidCountry
+	}
+	return nil
+}
+
+func validateCity(city string) error {
+	if utf8.RuneCountInString(city) > MaxCityLength {
+		return ErrInvalidCity
+	}
+	return nil
+}
+
+// IsCountryCode reports whether code is ISO 3166-1 alpha-2 country code, case is ignored
+func IsCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+
+	region, err := language.ParseRegion(code)
+	return err == nil && region.IsCountry()
+}
+
+// canonicalLocale returns canonical form of BCP 47 tag, e.g. "ru-RU" for "ru_ru"
+func canonicalLocale(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return ""
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return locale
+	}
+
+	return tag.String()
+}
+
+// collapseSpaces composes runes (NFC), trims and collapses whitespace
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(s)), " ")
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrContactNotSet        = errors.New("contact is not set")
+	ErrContactVerified      = errors.New("contact is already verified")
+	ErrVerificationNotFound = errors.New("no pending verification, request a new code")
+	ErrCodeExpired          = errors.New("verification code expired, request a new code")
+	ErrInvalidCode          = errors.New("invalid verification code")
+	ErrTooManyAttempts      = errors.New("too many attempts, request a new code")
+	ErrContactTaken         = errors.New("contact is verified by another account")
+)
+
+// ResendCooldownError is returned when code is requested again too soon
+type ResendCooldownError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ResendCooldownError) Error() string {
+	return fmt.Sprintf("verification code was sent recently, retry in %d seconds",
+		int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// Verification is pending code sent to account contact.
+// Only one code per channel is pending, new code replaces previous one.
+type Verification struct {
+	UserId  uuid.UUID
+	Channel string
+	// Target is contact value code was sent to, code is void once contact changes
+	Target string
+	// CodeHash is keyed hash of code, code itself is never stored
+	CodeHash []byte
+	// Attempts is number of failed confirmations
+	Attempts  int
+	ExpiresAt time.Time
+	SentAt    time.Time
+}
+
+// Check reports whether code may still be confirmed at now
+func (v Verification) Check(now time.Time, maxAttempts int) error {
+	if v.Attempts >= maxAttempts {
+		return ErrTooManyAttempts
+	}
+	if !now.Before(v.ExpiresAt) {
+		return ErrCodeExpired
+	}
+
+	return nil
+}
+
+// ResendAt returns time new code may be sent after cooldown
+func (v Verification) ResendAt(cooldown time.Duration) time.Time {
+	return v.SentAt.Add(cooldown)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerificationCheck(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		attempts  int
+		expiresAt time.Time
+		want      error
+	}{
+		{name: "pending", attempts: 4, expiresAt: now.Add(time.Second)},
+		{name: "attempt limit", attempts: 5, expiresAt: now.Add(time.Minute), want: ErrTooManyAttempts},
+		{name: "expires now", expiresAt: now, want: ErrCodeExpired},
+		{name: "expired", expiresAt: now.Add(-time.Second), want: ErrCodeExpired},
+		// Spent attempts are reported even for expired code
+		{name: "both", attempts: 5, expiresAt: now.Add(-time.Second), want: ErrTooManyAttempts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := Verification{Attempts: tt.attempts, ExpiresAt: tt.expiresAt}
+			if err := v.Check(now, 5); !errors.Is(err, tt.want) {
+				t.Fatalf("Check() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
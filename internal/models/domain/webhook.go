@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("no webhook with such id")
+	ErrDeliveryNotFound = errors.New("no webhook delivery with such id")
+)
+
+// PendingDelivery is webhook delivery claimed by dispatcher
+type PendingDelivery struct {
+	ID        int64
+	URL       string
+	Secret    string
+	EventType string
+	Payload   json.RawMessage
+	// Retries is number of attempts since delivery was created or replayed
+	Retries int
+}
+
+// DeliveryAttempt is result of single HTTP attempt
+type DeliveryAttempt struct {
+	DeliveryId int64
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	// Status and NextAttemptAt are new delivery state
+	Status        string
+	NextAttemptAt time.Time
+}
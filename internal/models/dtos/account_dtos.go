@@ -1,6 +1,7 @@
 package dtos
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,8 +14,21 @@ type CreateAccountRequest struct {
 	Firstname  string    `json:"firstname" validate:"required" example:"Иван"`
 	Surname    string    `json:"surname" validate:"required" example:"Иванов"`
 	Patronymic string    `json:"patronymic" example:"Иванович"`
-	Gender     string    `json:"gender" validate:"required,min=1,max=1" example:"M"`
-	Birthdate  string    `json:"birthdate" validate:"required" example:"1990-01-01"`
+	Gender     string    `json:"gender" validate:"required,gender" example:"M"`
+	Birthdate  Date      `json:"birthdate" validate:"required,birthdate" swaggertype:"string" format:"date" example:"1990-01-01"`
+	// Profile fields are optional
+	DisplayName string `json:"display_name" validate:"omitempty,max=64" example:"Ваня"`
+	Bio         string `json:"bio" validate:"omitempty,max=500" example:"Люблю горы и джаз"`
+	// Locale is BCP 47 language tag
+	Locale string `json:"locale" validate:"omitempty,locale" example:"ru-RU"`
+	// Timezone is IANA time zone name, age is calculated in it. UTC if empty
+	Timezone string `json:"timezone" validate:"omitempty,timezone" example:"Europe/Moscow"`
+	// Country is ISO 3166-1 alpha-2 code
+	Country string `json:"country" validate:"omitempty,country" example:"RU"`
+	City    string `json:"city" validate:"omitempty,max=128" example:"Москва"`
+	// Contacts are verified separately, see /api/v1/account/verification
+	Email string `json:"email" validate:"omitempty,max=254,email" example:"ivan@example.com"`
+	Phone string `json:"phone" validate:"omitempty,phone" example:"+79991234567"`
 }
 
 // GetAccountResponse represents account data
@@ -24,7 +38,200 @@ type GetAccountResponse struct {
 	Firstname  string    `json:"firstname" example:"Иван"`
 	Surname    string    `json:"surname" example:"Иванов"`
 	Patronymic string    `json:"patronymic" example:"Иванович"`
-	Gender     string    `json:"gender" example:"male"`
-	Age        int       `json:"age" example:"33"`
-	Birthdate  time.Time `json:"birthdate" example:"1990-01-01T00:00:00Z"`
+	Gender     string    `json:"gender" example:"M"`
+	// Age and Birthdate are omitted if birthdate is unknown
+	Age       *int  `json:"age,omitempty" example:"33"`
+	Birthdate *Date `json:"birthdate,omitempty" swaggertype:"string" format:"date" example:"1990-01-01"`
+	// Profile fields are omitted if not set
+	DisplayName string `json:"display_name,omitempty" example:"Ваня"`
+	Bio         string `json:"bio,omitempty" example:"Люблю горы и джаз"`
+	Locale      string `json:"locale,omitempty" example:"ru-RU"`
+	Timezone    string `json:"timezone,omitempty" example:"Europe/Moscow"`
+	Country     string `json:"country,omitempty" example:"RU"`
+	City        string `json:"city,omitempty" example:"Москва"`
+	// Contacts are omitted if not set
+	Email *ContactResponse `json:"email,omitempty"`
+	Phone *ContactResponse `json:"phone,omitempty"`
+	// AvatarURLs are square thumbnail URLs by side in pixels, omitted if avatar is not uploaded
+	AvatarURLs map[string]string `json:"avatar_urls,omitempty" example:"128:/api/v1/account/avatars/550e8400-e29b-41d4-a716-446655440000/6ba7b810-9dad-11d1-80b4-00c04fd430c8/128.jpg"`
+}
+
+// ContactResponse represents contact with verification status
+// swagger:model ContactResponse
+type ContactResponse struct {
+	Value      string     `json:"value" example:"ivan@example.com"`
+	Verified   bool       `json:"verified" example:"true"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// UpdateAccountRequest represents account update data, omitted fields are not changed
+// swagger:model UpdateAccountRequest
+type UpdateAccountRequest struct {
+	UserId     uuid.UUID `json:"-" swaggerignore:"true"`
+	Firstname  *string   `json:"firstname,omitempty" validate:"omitempty,min=1" example:"Иван"`
+	Surname    *string   `json:"surname,omitempty" validate:"omitempty,min=1" example:"Иванов"`
+	Patronymic *string   `json:"patronymic,omitempty" example:"Иванович"`
+	Gender     *string   `json:"gender,omitempty" validate:"omitempty,gender" example:"M"`
+	Birthdate  *Date     `json:"birthdate,omitempty" validate:"omitempty,birthdate" swaggertype:"string" format:"date" example:"1990-01-01"`
+	// Empty string unsets profile field, timezone is reset to UTC
+	DisplayName *string `json:"display_name,omitempty" validate:"omitempty,max=64" example:"Ваня"`
+	Bio         *string `json:"bio,omitempty" validate:"omitempty,max=500" example:"Люблю горы и джаз"`
+	Locale      *string `json:"locale,omitempty" validate:"omitempty,locale" example:"ru-RU"`
+	Timezone    *string `json:"timezone,omitempty" validate:"omitempty,timezone" example:"Europe/Moscow"`
+	Country     *string `json:"country,omitempty" validate:"omitempty,country" example:"RU"`
+	City        *string `json:"city,omitempty" validate:"omitempty,max=128" example:"Москва"`
+	// Changed contact must be verified again, empty string unsets it
+	Email *string `json:"email,omitempty" validate:"omitempty,max=254,email" example:"ivan@example.com"`
+	Phone *string `json:"phone,omitempty" validate:"omitempty,phone" example:"+79991234567"`
+}
+
+// SearchAccountsRequest represents account search filters
+type SearchAccountsRequest struct {
+	// Surname is matched by prefix, names match in either Cyrillic or Latin script
+	Surname   string `json:"surname" validate:"omitempty,max=255"`
+	Firstname string `json:"firstname" validate:"omitempty,max=255"`
+	Gender    string `json:"gender" validate:"omitempty,gender"`
+	Limit     int    `json:"limit" validate:"min=0,max=1000"`
+	Offset    int    `json:"offset" validate:"min=0"`
+}
+
+// BirthdaysRequest selects accounts with birthdays on days From..To inclusive
+type BirthdaysRequest struct {
+	From   Date `json:"from" validate:"required"`
+	To     Date `json:"to" validate:"required"`
+	Limit  int  `json:"limit" validate:"min=0,max=1000"`
+	Offset int  `json:"offset" validate:"min=0"`
+}
+
+// BirthdayResponse represents account birthday in requested range
+// swagger:model BirthdayResponse
+type BirthdayResponse struct {
+	Account GetAccountResponse `json:"account"`
+	// Date is the day birthday is celebrated on, February 29 birthdays are on March 1 in non-leap years
+	Date Date `json:"date" swaggertype:"string" format:"date" example:"2025-01-01"`
+	// TurnsAge is the age account holder turns on Date
+	TurnsAge int `json:"turns_age" example:"35"`
+}
+
+// GenderResponse represents allowed gender value
+// swagger:model GenderResponse
+type GenderResponse struct {
+	Code string `json:"code" example:"M"`
+	// Label is display name in requested language
+	Label string `json:"label" example:"Male"`
+}
+
+// FuzzySearchAccountsRequest represents full name search with typos
+type FuzzySearchAccountsRequest struct {
+	// Query is any part of full name in Cyrillic or Latin script
+	Query  string `json:"q" validate:"required,min=2,max=255"`
+	Limit  int    `json:"limit" validate:"min=0,max=100"`
+	Offset int    `json:"offset" validate:"min=0"`
+}
+
+// AccountMatchResponse represents account found by fuzzy search
+// swagger:model AccountMatchResponse
+type AccountMatchResponse struct {
+	Account GetAccountResponse `json:"account"`
+	// Rank is relevance of match, higher is better
+	Rank float64 `json:"rank" example:"0.83"`
+}
+
+// SendVerificationRequest requests code for account contact
+type SendVerificationRequest struct {
+	UserId uuid.UUID `json:"-"`
+	// Channel is taken from path
+	Channel string `json:"channel" validate:"required,oneof=email phone"`
+	// Language of message with code
+	Language string `json:"-"`
+}
+
+// VerificationSentResponse represents sent verification code
+// swagger:model VerificationSentResponse
+type VerificationSentResponse struct {
+	Channel string `json:"channel" example:"email"`
+	// Target is masked contact code was sent to
+	Target    string    `json:"target" example:"i***@example.com"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// ResendAt is the earliest time new code may be requested
+	ResendAt time.Time `json:"resend_at"`
+}
+
+// ConfirmVerificationRequest represents code confirmation
+// swagger:model ConfirmVerificationRequest
+type ConfirmVerificationRequest struct {
+	UserId uuid.UUID `json:"-" swaggerignore:"true"`
+	// Channel is taken from path
+	Channel string `json:"channel" swaggerignore:"true" validate:"required,oneof=email phone"`
+	Code    string `json:"code" validate:"required,numeric,max=10" example:"123456"`
+}
+
+// AvatarResponse represents uploaded avatar
+// swagger:model AvatarResponse
+type AvatarResponse struct {
+	// URLs are square thumbnail URLs by side in pixels
+	URLs       map[string]string `json:"urls" example:"128:/api/v1/account/avatars/550e8400-e29b-41d4-a716-446655440000/6ba7b810-9dad-11d1-80b4-00c04fd430c8/128.jpg"`
+	UploadedAt time.Time         `json:"uploaded_at"`
+}
+
+// ImportAccountRecord is a single row of bulk account import
+type ImportAccountRecord struct {
+	UserId     string `json:"user_id"`
+	Firstname  string `json:"firstname"`
+	Surname    string `json:"surname"`
+	Patronymic string `json:"patronymic"`
+	Gender     string `json:"gender"`
+	Birthdate  string `json:"birthdate"`
+}
+
+// ImportReject describes a row rejected during bulk import
+type ImportReject struct {
+	Line   int    `json:"line" example:"42"`
+	Error  string `json:"error" example:"Firstname is required"`
+	Record string `json:"record"`
+}
+
+// ImportResult represents bulk import summary
+// swagger:model ImportResult
+type ImportResult struct {
+	// LastLine is the last processed line, pass it as resume_from to continue
+	LastLine  int            `json:"last_line" example:"1000"`
+	Processed int            `json:"processed" example:"1000"`
+	Imported  int            `json:"imported" example:"998"`
+	Rejected  int            `json:"rejected" example:"2"`
+	Rejects   []ImportReject `json:"rejects,omitempty"`
+	// Truncated is set when rejects hold only first of rejected rows
+	Truncated bool `json:"truncated" example:"false"`
+}
+
+// ExportAccountRecord is a single row of bulk account export
+type ExportAccountRecord struct {
+	UserId     string    `json:"user_id" parquet:"user_id"`
+	Firstname  string    `json:"firstname" parquet:"firstname"`
+	Surname    string    `json:"surname" parquet:"surname"`
+	Patronymic string    `json:"patronymic" parquet:"patronymic"`
+	Gender     string    `json:"gender" parquet:"gender"`
+	Birthdate  string    `json:"birthdate" parquet:"birthdate"`
+	CreatedAt  time.Time `json:"created_at" parquet:"created_at,timestamp(millisecond)"`
+	UpdatedAt  time.Time `json:"updated_at" parquet:"updated_at,timestamp(millisecond)"`
+}
+
+// ExportAccountsRequest represents bulk export filters
+type ExportAccountsRequest struct {
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Gender      string `validate:"omitempty,gender"`
+	// MaskPII hides names and birth day and month
+	MaskPII bool
+}
+
+// AccountEvent is data of account change event in SSE stream
+// swagger:model AccountEvent
+type AccountEvent struct {
+	ID     int64     `json:"id" example:"1024"`
+	Type   string    `json:"type" example:"account.updated"`
+	UserId uuid.UUID `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	// Account is state after change, only user_id is set for deleted account
+	Account   json.RawMessage `json:"account" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
 }
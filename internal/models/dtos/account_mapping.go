@@ -0,0 +1,244 @@
+package dtos
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+)
+
+// ToDomain creates account from request, request must be validated first.
+// Gender alias is replaced with its code of genders.
+func (r CreateAccountRequest) ToDomain(genders domain.Genders) (domain.Account, error) {
+	gender, err := canonicalGender(genders, r.Gender)
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	return domain.NewAccount(r.UserId, r.Firstname, r.Surname, r.Patronymic, gender,
+		r.Birthdate.Time(), domain.Profile{
+			DisplayName: r.DisplayName,
+			Bio:         r.Bio,
+			Locale:      r.Locale,
+			Timezone:    r.Timezone,
+			Country:     r.Country,
+			City:        r.City,
+		}, domain.Contacts{
+			Email: domain.Contact{Value: r.Email},
+			Phone: domain.Contact{Value: r.Phone},
+		})
+}
+
+// ToDomain returns normalized changes of request, request must be validated first
+func (r UpdateAccountRequest) ToDomain(genders domain.Genders) (domain.AccountChanges, error) {
+	changes := domain.AccountChanges{
+		UserId:     r.UserId,
+		Firstname:  r.Firstname,
+		Surname:    r.Surname,
+		Patronymic: r.Patronymic,
+		ProfileChanges: domain.ProfileChanges{
+			DisplayName: r.DisplayName,
+			Bio:         r.Bio,
+			Locale:      r.Locale,
+			Timezone:    r.Timezone,
+			Country:     r.Country,
+			City:        r.City,
+		},
+		ContactChanges: domain.ContactChanges{
+			Email: r.Email,
+			Phone: r.Phone,
+		},
+	}
+
+	if r.Gender != nil {
+		gender, err := canonicalGender(genders, *r.Gender)
+		if err != nil {
+			return domain.AccountChanges{}, err
+		}
+		changes.Gender = &gender
+	}
+
+	if r.Birthdate != nil {
+		birthdate := r.Birthdate.Time()
+		changes.Birthdate = &birthdate
+	}
+
+	return changes.Normalized(), nil
+}
+
+func (r SearchAccountsRequest) ToDomain(genders domain.Genders) (domain.AccountFilter, error) {
+	gender, err := canonicalGender(genders, r.Gender)
+	if err != nil {
+		return domain.AccountFilter{}, err
+	}
+
+	return domain.AccountFilter{
+		Surname:   r.Surname,
+		Firstname: r.Firstname,
+		Gender:    gender,
+		Limit:     r.Limit,
+		Offset:    r.Offset,
+	}, nil
+}
+
+func (r ExportAccountsRequest) ToDomain(genders domain.Genders) (domain.AccountFilter, error) {
+	gender, err := canonicalGender(genders, r.Gender)
+	if err != nil {
+		return domain.AccountFilter{}, err
+	}
+
+	return domain.AccountFilter{
+		Gender:      gender,
+		CreatedFrom: r.CreatedFrom,
+		CreatedTo:   r.CreatedTo,
+	}, nil
+}
+
+// canonicalGender replaces gender alias with its code, empty gender is kept
+func canonicalGender(genders domain.Genders, gender string) (string, error) {
+	if gender == "" {
+		return "", nil
+	}
+
+	return genders.Parse(gender)
+}
+
+// NewGetAccountResponse maps account to response, age is calculated at now
+func NewGetAccountResponse(a domain.Account, now time.Time) GetAccountResponse {
+	response := GetAccountResponse{
+		UserId:     a.UserId,
+		Firstname:  a.Firstname,
+		Surname:    a.Surname,
+		Patronymic: a.Patronymic,
+		Gender:     a.Gender,
+
+		DisplayName: a.DisplayName,
+		Bio:         a.Bio,
+		Locale:      a.Locale,
+		Timezone:    a.Timezone,
+		Country:     a.Country,
+		City:        a.City,
+
+		Email: newContactResponse(a.Email),
+		Phone: newContactResponse(a.Phone),
+
+		AvatarURLs: avatarURLs(a.Avatar),
+	}
+
+	if a.HasBirthdate() {
+		age, birthdate := a.Age(now), DateOf(a.Birthdate)
+		response.Age, response.Birthdate = &age, &birthdate
+	}
+
+	return response
+}
+
+func avatarURLs(a *domain.Avatar) map[string]string {
+	if a == nil {
+		return nil
+	}
+	return a.URLs
+}
+
+func NewAvatarResponse(a domain.Avatar) AvatarResponse {
+	return AvatarResponse{
+		URLs:       a.URLs,
+		UploadedAt: a.UploadedAt,
+	}
+}
+
+// newContactResponse returns nil for contact that is not set
+func newContactResponse(c domain.Contact) *ContactResponse {
+	if c.Value == "" {
+		return nil
+	}
+
+	return &ContactResponse{
+		Value:      c.Value,
+		Verified:   c.Verified(),
+		VerifiedAt: c.VerifiedAt,
+	}
+}
+
+func NewGetAccountResponses(accounts []domain.Account, now time.Time) []GetAccountResponse {
+	responses := make([]GetAccountResponse, 0, len(accounts))
+	for _, a := range accounts {
+		responses = append(responses, NewGetAccountResponse(a, now))
+	}
+
+	return responses
+}
+
+// NewBirthdayResponses maps birthdays to response, current age is calculated at now
+func NewBirthdayResponses(birthdays []domain.Birthday, now time.Time) []BirthdayResponse {
+	responses := make([]BirthdayResponse, 0, len(birthdays))
+	for _, b := range birthdays {
+		responses = append(responses, BirthdayResponse{
+			Account:  NewGetAccountResponse(b.Account, now),
+			Date:     DateOf(b.Date),
+			TurnsAge: b.Age,
+		})
+	}
+
+	return responses
+}
+
+// NewAccountMatchResponses maps search matches to response, age is calculated at now
+func NewAccountMatchResponses(matches []domain.AccountMatch, now time.Time) []AccountMatchResponse {
+	responses := make([]AccountMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		responses = append(responses, AccountMatchResponse{
+			Account: NewGetAccountResponse(m.Account, now),
+			Rank:    m.Rank,
+		})
+	}
+
+	return responses
+}
+
+func NewExportAccountRecord(a domain.Account) ExportAccountRecord {
+	record := ExportAccountRecord{
+		UserId:     a.UserId.String(),
+		Firstname:  a.Firstname,
+		Surname:    a.Surname,
+		Patronymic: a.Patronymic,
+		Gender:     a.Gender,
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+	}
+
+	// Unknown birthdate is exported empty
+	if a.HasBirthdate() {
+		record.Birthdate = DateOf(a.Birthdate).String()
+	}
+
+	return record
+}
+
+// NewVerificationSentResponse maps sent code to response, resend is allowed after cooldown
+func NewVerificationSentResponse(v domain.Verification, cooldown time.Duration) VerificationSentResponse {
+	return VerificationSentResponse{
+		Channel:   v.Channel,
+		Target:    maskContact(v.Channel, v.Target),
+		ExpiresAt: v.ExpiresAt,
+		ResendAt:  v.ResendAt(cooldown),
+	}
+}
+
+// maskContact keeps first letter of email user and last digits of phone
+func maskContact(channel, value string) string {
+	if channel == domain.ChannelEmail {
+		at := strings.LastIndexByte(value, '@')
+		if at < 1 {
+			return "***"
+		}
+		first, _ := utf8.DecodeRuneInString(value)
+		return string(first) + "***" + value[at:]
+	}
+
+	if len(value) < 8 {
+		return "***"
+	}
+	return value[:2] + strings.Repeat("*", len(value)-6) + value[len(value)-4:]
+}
@@ -0,0 +1,72 @@
+package dtos
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/google/uuid"
+)
+
+func TestMaskContact(t *testing.T) {
+	tests := []struct {
+		channel string
+		value   string
+		want    string
+	}{
+		{domain.ChannelEmail, "ivan@example.com", "i***@example.com"},
+		{domain.ChannelEmail, "иван@example.com", "и***@example.com"},
+		{domain.ChannelEmail, "@example.com", "***"},
+		{domain.ChannelEmail, "ivan", "***"},
+		{domain.ChannelPhone, "+79991234567", "+7******4567"},
+		{domain.ChannelPhone, "+1234567", "+1**4567"},
+		{domain.ChannelPhone, "+123456", "***"},
+	}
+
+	for _, tt := range tests {
+		if got := maskContact(tt.channel, tt.value); got != tt.want {
+			t.Errorf("maskContact(%q, %q) = %q, want %q", tt.channel, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestToDomainResolvesGenderAlias(t *testing.T) {
+	genders, err := domain.NewGenderCatalog()
+	if err != nil {
+		t.Fatalf("NewGenderCatalog() error = %v", err)
+	}
+
+	create := CreateAccountRequest{
+		UserId:    uuid.New(),
+		Firstname: "иван",
+		Surname:   "иванов",
+		Gender:    "муж",
+		Birthdate: NewDate(1990, 1, 1),
+	}
+	account, err := create.ToDomain(genders)
+	if err != nil {
+		t.Fatalf("CreateAccountRequest.ToDomain() error = %v", err)
+	}
+	if account.Gender != domain.GenderMale || account.Firstname != "Иван" {
+		t.Errorf("account gender = %q, firstname = %q, want M and normalized name", account.Gender, account.Firstname)
+	}
+
+	alias := "female"
+	changes, err := UpdateAccountRequest{UserId: create.UserId, Gender: &alias}.ToDomain(genders)
+	if err != nil {
+		t.Fatalf("UpdateAccountRequest.ToDomain() error = %v", err)
+	}
+	if changes.Gender == nil || *changes.Gender != domain.GenderFemale {
+		t.Errorf("changes gender = %v, want F", changes.Gender)
+	}
+
+	filter, err := SearchAccountsRequest{}.ToDomain(genders)
+	if err != nil || filter.Gender != "" {
+		t.Errorf("SearchAccountsRequest.ToDomain() = %+v, %v, want no gender filter", filter, err)
+	}
+
+	create.Gender = "unknown gender"
+	if _, err := create.ToDomain(genders); !errors.Is(err, domain.ErrInvalidGender) {
+		t.Errorf("ToDomain() with unknown gender error = %v, want %v", err, domain.ErrInvalidGender)
+	}
+}
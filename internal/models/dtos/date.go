@@ -0,0 +1,81 @@
+package dtos
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateLayout is ISO 8601 calendar date
+const DateLayout = time.DateOnly
+
+// Date is calendar date without time of day, encoded as YYYY-MM-DD
+type Date time.Time
+
+// DateError is returned for date not in YYYY-MM-DD format
+type DateError struct {
+	Value string
+}
+
+func (e *DateError) Error() string {
+	return fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", e.Value)
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns calendar date of t in its location
+func DateOf(t time.Time) Date {
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+// ParseDate strictly parses YYYY-MM-DD, out of range days like 1990-02-30 are rejected
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return Date{}, &DateError{Value: s}
+	}
+
+	return Date(t), nil
+}
+
+// Time returns midnight UTC of date
+func (d Date) Time() time.Time {
+	return time.Time(d)
+}
+
+func (d Date) IsZero() bool {
+	return d.Time().IsZero()
+}
+
+func (d Date) String() string {
+	return d.Time().Format(DateLayout)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	date, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = date
+	return nil
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return &DateError{Value: string(data)}
+	}
+
+	return d.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,81 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	date, err := ParseDate("1990-01-31")
+	if err != nil {
+		t.Fatalf("ParseDate() error = %v", err)
+	}
+	if want := time.Date(1990, time.January, 31, 0, 0, 0, 0, time.UTC); !date.Time().Equal(want) {
+		t.Errorf("ParseDate() = %v, want %v", date.Time(), want)
+	}
+
+	invalid := []string{
+		"1990-1-1",
+		"90-01-01",
+		"1990-02-30",
+		"1990-13-01",
+		"1990-01-01T00:00:00Z",
+		"1990-01-01 00:00:00",
+		"01.01.1990",
+		" 1990-01-01",
+		"",
+	}
+
+	for _, value := range invalid {
+		_, err := ParseDate(value)
+
+		var dateErr *DateError
+		if !errors.As(err, &dateErr) {
+			t.Errorf("ParseDate(%q) error = %v, want DateError", value, err)
+			continue
+		}
+		if dateErr.Value != value {
+			t.Errorf("ParseDate(%q) error value = %q", value, dateErr.Value)
+		}
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	var request struct {
+		Birthdate Date `json:"birthdate"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"birthdate":"2000-02-29"}`), &request); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := request.Birthdate.String(); got != "2000-02-29" {
+		t.Errorf("Birthdate = %s, want 2000-02-29", got)
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"birthdate":"2000-02-29"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	invalid := []string{
+		`{"birthdate":"1990-1-1"}`,
+		`{"birthdate":"1990-02-30"}`,
+		`{"birthdate":"1990-01-01T00:00:00Z"}`,
+		`{"birthdate":19900101}`,
+		`{"birthdate":true}`,
+		`{"birthdate":{}}`,
+		`{"birthdate":["1990-01-01"]}`,
+	}
+
+	for _, body := range invalid {
+		var dateErr *DateError
+		if err := json.Unmarshal([]byte(body), &request); !errors.As(err, &dateErr) {
+			t.Errorf("Unmarshal(%s) error = %v, want DateError", body, err)
+		}
+	}
+}
@@ -3,6 +3,21 @@ package dtos
 // Response represents common API response
 // swagger:model Response
 type Response struct {
-	Status  int `json:"status" example:"200"`
-	Message any `json:"message"`
+	Status    int    `json:"status" example:"200"`
+	Message   any    `json:"message"`
+	RequestId string `json:"request_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// ValidationError describes invalid request field
+// swagger:model ValidationError
+type ValidationError struct {
+	Field   string `json:"field" example:"firstname"`
+	Tag     string `json:"tag" example:"required"`
+	Message string `json:"message" example:"поле firstname обязательно"`
+}
+
+// ValidationErrors is message of response to invalid request
+// swagger:model ValidationErrors
+type ValidationErrors struct {
+	Errors []ValidationError `json:"errors"`
 }
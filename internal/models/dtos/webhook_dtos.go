@@ -0,0 +1,68 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// CreateWebhookRequest represents webhook subscription data
+// swagger:model CreateWebhookRequest
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,startswith=http" example:"https://partner.example.com/hooks/accounts"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=account.created account.updated account.deleted" example:"account.created,account.updated"`
+	// Secret signs deliveries with HMAC-SHA256, it is never returned back
+	Secret string `json:"secret" validate:"required,min=16,max=255" example:"3f1c9a7e5b2d4f6a8c0e"`
+}
+
+// WebhookSubscription represents registered webhook
+// swagger:model WebhookSubscription
+type WebhookSubscription struct {
+	ID         uuid.UUID `json:"id" example:"1b4e28ba-2fa1-11d2-883f-0016d3cca427"`
+	URL        string    `json:"url" example:"https://partner.example.com/hooks/accounts"`
+	EventTypes []string  `json:"event_types" example:"account.created,account.updated"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ListWebhookDeliveriesRequest represents delivery log filters
+type ListWebhookDeliveriesRequest struct {
+	SubscriptionId uuid.UUID `json:"subscription_id"`
+	Status         string    `json:"status" validate:"omitempty,oneof=pending succeeded failed"`
+	Limit          int       `json:"limit" validate:"min=0,max=1000"`
+	Offset         int       `json:"offset" validate:"min=0"`
+}
+
+// WebhookDelivery represents delivery of an event to a subscription
+// swagger:model WebhookDelivery
+type WebhookDelivery struct {
+	ID             int64      `json:"id" example:"42"`
+	SubscriptionId uuid.UUID  `json:"subscription_id" example:"1b4e28ba-2fa1-11d2-883f-0016d3cca427"`
+	EventId        int64      `json:"event_id" example:"1024"`
+	EventType      string     `json:"event_type" example:"account.updated"`
+	Status         string     `json:"status" example:"failed"`
+	Attempts       int        `json:"attempts" example:"3"`
+	LastStatusCode *int       `json:"last_status_code,omitempty" example:"502"`
+	LastError      *string    `json:"last_error,omitempty" example:"unexpected status 502"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	// Log is filled only for single delivery
+	Log []WebhookAttempt `json:"log,omitempty"`
+}
+
+// WebhookAttempt represents single HTTP attempt of a delivery
+// swagger:model WebhookAttempt
+type WebhookAttempt struct {
+	Attempt    int       `json:"attempt" example:"1"`
+	StatusCode *int      `json:"status_code,omitempty" example:"502"`
+	Error      *string   `json:"error,omitempty" example:"unexpected status 502"`
+	DurationMs int64     `json:"duration_ms" example:"120"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Account domain event types
+const (
+	AccountCreated = "account.created"
+	AccountUpdated = "account.updated"
+	AccountDeleted = "account.deleted"
+)
+
+// AccountPayload is account state carried by event
+type AccountPayload struct {
+	UserId        uuid.UUID `json:"user_id"`
+	Firstname     string    `json:"firstname,omitempty"`
+	Surname       string    `json:"surname,omitempty"`
+	Patronymic    string    `json:"patronymic,omitempty"`
+	Gender        string    `json:"gender,omitempty"`
+	Birthdate     string    `json:"birthdate,omitempty"`
+	DisplayName   string    `json:"display_name,omitempty"`
+	Bio           string    `json:"bio,omitempty"`
+	Locale        string    `json:"locale,omitempty"`
+	Timezone      string    `json:"timezone,omitempty"`
+	Country       string    `json:"country,omitempty"`
+	City          string    `json:"city,omitempty"`
+	Email         string    `json:"email,omitempty"`
+	EmailVerified bool      `json:"email_verified,omitempty"`
+	Phone         string    `json:"phone,omitempty"`
+	PhoneVerified bool      `json:"phone_verified,omitempty"`
+	// AvatarURLs are thumbnail URLs by side in pixels
+	AvatarURLs map[string]string `json:"avatar_urls,omitempty"`
+}
+
+// Message is a published domain event.
+// Key is aggregate id, messages with the same key are delivered in order.
+type Message struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	Key       string          `json:"key"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types consumed from auth service
+const (
+	UserRegistered = "user.registered"
+)
+
+// UserRegisteredPayload is published by auth service after sign up.
+// Profile fields are optional, missing ones are filled with placeholders.
+type UserRegisteredPayload struct {
+	UserId     uuid.UUID `json:"user_id"`
+	Firstname  string    `json:"firstname,omitempty"`
+	Surname    string    `json:"surname,omitempty"`
+	Patronymic string    `json:"patronymic,omitempty"`
+}
+
+// InboundMessage is event received from other services.
+// ID is unique per event and is used for deduplication.
+type InboundMessage struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// DeadLetter is inbound message which could not be processed
+type DeadLetter struct {
+	EventID   string    `json:"event_id" db:"event_id"`
+	EventType string    `json:"event_type" db:"event_type"`
+	Payload   string    `json:"payload" db:"payload"`
+	Error     string    `json:"error" db:"error"`
+	Attempts  int       `json:"attempts" db:"attempts"`
+	FailedAt  time.Time `json:"failed_at" db:"failed_at"`
+}
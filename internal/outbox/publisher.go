@@ -0,0 +1,122 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/models/events"
+)
+
+// Publisher delivers domain events to a broker.
+// Delivery is at-least-once, consumers should deduplicate by message id.
+type Publisher interface {
+	Publish(ctx context.Context, msg events.Message) error
+}
+
+// MemoryPublisher keeps published messages in memory, for local runs and tests
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []events.Message
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, msg events.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+// Messages returns copy of published messages
+func (p *MemoryPublisher) Messages() []events.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]events.Message(nil), p.messages...)
+}
+
+// WriterPublisher writes messages as NDJSON, e.g. to stdout or file
+type WriterPublisher struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{encoder: json.NewEncoder(w)}
+}
+
+// NewFilePublisher appends messages to file at path
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox file: %w", err)
+	}
+
+	return &WriterPublisher{encoder: json.NewEncoder(file), closer: file}, nil
+}
+
+func (p *WriterPublisher) Publish(_ context.Context, msg events.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.encoder.Encode(msg)
+}
+
+func (p *WriterPublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+
+	return p.closer.Close()
+}
+
+// NewPublisherFromConfig creates publisher selected in config.
+// Returned close function releases publisher resources.
+func NewPublisherFromConfig(cfg config.OutboxConfig) (Publisher, func() error, error) {
+	noop := func() error { return nil }
+
+	switch cfg.Publisher {
+	case "stdout":
+		return NewWriterPublisher(os.Stdout), noop, nil
+	case "memory":
+		return NewMemoryPublisher(), noop, nil
+	case "file":
+		publisher, err := NewFilePublisher(cfg.FilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return publisher, publisher.Close, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown outbox publisher: %s", cfg.Publisher)
+}
+
+// MultiPublisher publishes every message to all publishers.
+// Message is retried if any of them fails, so others may receive it twice.
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, msg events.Message) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+)
+
+type Repository interface {
+	ProcessBatch(ctx context.Context, limit int,
+		publish func(events.Message) error, backoff func(attempts int) time.Duration) (int, error)
+}
+
+type RelayOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Retry delay doubles from MinBackoff up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Relay publishes pending outbox events
+type Relay struct {
+	repository Repository
+	publisher  Publisher
+	logger     *slog.Logger
+	opts       RelayOptions
+}
+
+func NewRelay(r Repository, p Publisher, l *slog.Logger, opts RelayOptions) *Relay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = time.Second
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = 5 * time.Minute
+	}
+
+	return &Relay{
+		repository: r,
+		publisher:  p,
+		logger:     l,
+		opts:       opts,
+	}
+}
+
+// Run polls outbox until ctx is canceled
+func (r *Relay) Run(ctx context.Context) {
+	r.logger.Info("outbox relay started")
+	defer r.logger.Info("outbox relay stopped")
+
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain outbox while full batches are returned
+		for {
+			processed, err := r.repository.ProcessBatch(ctx, r.opts.BatchSize, r.publish(ctx), r.backoff)
+			if err != nil {
+				if ctx.Err() == nil {
+					r.logger.Error("outbox relay batch failed", slogerr.Error(err))
+				}
+				break
+			}
+			if processed < r.opts.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) publish(ctx context.Context) func(events.Message) error {
+	return func(msg events.Message) error {
+		err := r.publisher.Publish(ctx, msg)
+		if err != nil {
+			r.logger.Warn("failed to publish event",
+				"event_id", msg.ID,
+				"event_type", msg.Type,
+				"key", msg.Key,
+				slogerr.Error(err),
+			)
+		}
+
+		return err
+	}
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := float64(r.opts.MinBackoff) * math.Pow(2, float64(attempts-1))
+	if delay > float64(r.opts.MaxBackoff) {
+		return r.opts.MaxBackoff
+	}
+
+	return time.Duration(delay)
+}
@@ -11,6 +11,8 @@ func JSON(w http.ResponseWriter, statusCode int, message any) {
 	response := dtos.Response{
 		Status:  statusCode,
 		Message: message,
+		// Header is set by request id middleware before handler is called
+		RequestId: w.Header().Get("X-Request-ID"),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,21 @@
+package bulk
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseTime accepts RFC 3339 timestamp or YYYY-MM-DD date, empty value gives nil
+func ParseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid time %q, expected RFC 3339 or YYYY-MM-DD", value)
+}
@@ -0,0 +1,165 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/WebChads/AccountService/internal/models/dtos"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// maxLineSize limits single NDJSON line
+const maxLineSize = 1 << 20
+
+// Row is a single decoded import row.
+// Err is set if row could not be decoded, reading can go on after that.
+type Row struct {
+	Line   int
+	Record dtos.ImportAccountRecord
+	Raw    string
+	Err    error
+}
+
+// Reader streams import rows, Next returns io.EOF when input is over
+type Reader interface {
+	Next() (Row, error)
+}
+
+func NewReader(r io.Reader, format string) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	}
+
+	return nil, fmt.Errorf("unknown import format %q", format)
+}
+
+// FormatFromPath guesses format by file extension
+func FormatFromPath(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".csv"):
+		return FormatCSV
+	case strings.HasSuffix(path, ".ndjson"), strings.HasSuffix(path, ".jsonl"):
+		return FormatNDJSON
+	}
+
+	return ""
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *ndjsonReader) Next() (Row, error) {
+	for r.scanner.Scan() {
+		r.line++
+
+		raw := r.scanner.Text()
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		row := Row{Line: r.line, Raw: raw}
+		if err := json.Unmarshal([]byte(raw), &row.Record); err != nil {
+			row.Err = fmt.Errorf("invalid json: %w", err)
+		}
+
+		return row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return Row{}, err
+	}
+
+	return Row{}, io.EOF
+}
+
+var csvColumns = []string{"user_id", "firstname", "surname", "patronymic", "gender", "birthdate"}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv header is missing")
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	var missing []string
+	for _, name := range csvColumns {
+		if _, ok := columns[name]; !ok && name != "patronymic" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("csv header misses columns: %s", strings.Join(missing, ", "))
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvReader) Next() (Row, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return Row{}, io.EOF
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return Row{Line: parseErr.Line, Err: err}, nil
+		}
+
+		return Row{}, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+
+	get := func(name string) string {
+		if i, ok := r.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	return Row{
+		Line: line,
+		Raw:  strings.Join(record, ","),
+		Record: dtos.ImportAccountRecord{
+			UserId:     get("user_id"),
+			Firstname:  get("firstname"),
+			Surname:    get("surname"),
+			Patronymic: get("patronymic"),
+			Gender:     get("gender"),
+			Birthdate:  get("birthdate"),
+		},
+	}, nil
+}
@@ -0,0 +1,125 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/parquet-go/parquet-go"
+)
+
+const FormatParquet = "parquet"
+
+// parquetRowGroupSize bounds number of rows buffered in memory
+const parquetRowGroupSize = 10000
+
+// Writer streams export records, Close flushes buffered data
+type Writer interface {
+	Write(record dtos.ExportAccountRecord) error
+	Close() error
+}
+
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return &parquetWriter{writer: parquet.NewGenericWriter[dtos.ExportAccountRecord](w)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// ContentType returns MIME type of export format
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	}
+
+	return "application/octet-stream"
+}
+
+var csvExportColumns = []string{
+	"user_id", "firstname", "surname", "patronymic",
+	"gender", "birthdate", "created_at", "updated_at",
+}
+
+// csvWriter writes header lazily, so nothing is sent until first record or Close
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+
+	return w.writer.Write(csvExportColumns)
+}
+
+func (w *csvWriter) Write(r dtos.ExportAccountRecord) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	return w.writer.Write([]string{
+		r.UserId, r.Firstname, r.Surname, r.Patronymic, r.Gender, r.Birthdate,
+		r.CreatedAt.Format(time.RFC3339), r.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(r dtos.ExportAccountRecord) error {
+	return w.encoder.Encode(r)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+type parquetWriter struct {
+	writer   *parquet.GenericWriter[dtos.ExportAccountRecord]
+	buffered int
+}
+
+func (w *parquetWriter) Write(r dtos.ExportAccountRecord) error {
+	if _, err := w.writer.Write([]dtos.ExportAccountRecord{r}); err != nil {
+		return err
+	}
+
+	w.buffered++
+	if w.buffered >= parquetRowGroupSize {
+		w.buffered = 0
+		return w.writer.Flush()
+	}
+
+	return nil
+}
+
+func (w *parquetWriter) Close() error {
+	return w.writer.Close()
+}
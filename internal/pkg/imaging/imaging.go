@@ -0,0 +1,118 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("image must be JPEG, PNG, GIF or WebP")
+	ErrTooManyPixels     = errors.New("image dimensions are too large")
+	ErrInvalidImage      = errors.New("image is corrupted")
+)
+
+type format struct {
+	decode       func(io.Reader) (image.Image, error)
+	decodeConfig func(io.Reader) (image.Config, error)
+}
+
+// formats are decoders by sniffed content type
+var formats = map[string]format{
+	"image/jpeg": {jpeg.Decode, jpeg.DecodeConfig},
+	"image/png":  {png.Decode, png.DecodeConfig},
+	"image/gif":  {gif.Decode, gif.DecodeConfig},
+	"image/webp": {webp.Decode, webp.DecodeConfig},
+}
+
+// jpegQuality is quality of encoded JPEG images
+const jpegQuality = 85
+
+// Sniff returns content type detected from data, declared type is never trusted
+func Sniff(data []byte) string {
+	return http.DetectContentType(data)
+}
+
+// Decode decodes image of supported type. Dimensions are checked before
+// pixels are allocated, so small files can't expand into huge images.
+// EXIF orientation of JPEG is returned, as metadata is not kept in image.
+func Decode(data []byte, maxPixels int) (_ image.Image, orientation int, err error) {
+	contentType := Sniff(data)
+
+	f, ok := formats[contentType]
+	if !ok {
+		return nil, 0, ErrUnsupportedFormat
+	}
+
+	cfg, err := f.decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, ErrInvalidImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, 0, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, 0, ErrTooManyPixels
+	}
+
+	img, err := f.decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, ErrInvalidImage
+	}
+
+	orientation = 1
+	if contentType == "image/jpeg" {
+		orientation = jpegOrientation(data)
+	}
+
+	return img, orientation, nil
+}
+
+// Square crops center square of img and scales it to side x side
+func Square(img image.Image, side int) *image.RGBA {
+	b := img.Bounds()
+
+	crop := min(b.Dx(), b.Dy())
+	x := b.Min.X + (b.Dx()-crop)/2
+	y := b.Min.Y + (b.Dy()-crop)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, image.Rect(x, y, x+crop, y+crop), draw.Src, nil)
+
+	return dst
+}
+
+// Encode encodes image without any metadata: opaque images as JPEG
+// and others as PNG to keep transparency. Content type is returned.
+func Encode(img *image.RGBA) (data []byte, contentType string, err error) {
+	var buf bytes.Buffer
+
+	if img.Opaque() {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+		contentType = "image/jpeg"
+	} else {
+		err = png.Encode(&buf, img)
+		contentType = "image/png"
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), contentType, nil
+}
+
+// Extension returns file extension of encoded content type
+func Extension(contentType string) string {
+	if contentType == "image/png" {
+		return ".png"
+	}
+	return ".jpg"
+}
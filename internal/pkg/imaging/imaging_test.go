@@ -0,0 +1,69 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestDecodeChecksPixelsBeforeDecoding(t *testing.T) {
+	data := encodePNG(t, 10, 10)
+
+	if _, _, err := Decode(data, 99); !errors.Is(err, ErrTooManyPixels) {
+		t.Fatalf("Decode() error = %v, want ErrTooManyPixels", err)
+	}
+
+	img, orientation, err := Decode(data, 100)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if img.Bounds().Dx() != 10 || orientation != 1 {
+		t.Fatalf("Decode() = %v, orientation %d, want 10x10 upright", img.Bounds(), orientation)
+	}
+}
+
+func TestDecodeRejectsInvalidData(t *testing.T) {
+	if _, _, err := Decode([]byte("GIF87a"), 100); !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("Decode() of corrupted gif error = %v, want ErrInvalidImage", err)
+	}
+	if _, _, err := Decode([]byte("<svg></svg>"), 100); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Decode() of svg error = %v, want ErrUnsupportedFormat", err)
+	}
+
+	// PNG cut after header decodes config but not pixels
+	data := encodePNG(t, 10, 10)
+	if _, _, err := Decode(data[:40], 100); !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("Decode() of truncated png error = %v, want ErrInvalidImage", err)
+	}
+}
+
+func TestSquareCropsCenter(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 30, 10))
+	for y := 0; y < 10; y++ {
+		for x := 10; x < 20; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	dst := Square(img, 4)
+	if dst.Bounds().Dx() != 4 || dst.Bounds().Dy() != 4 {
+		t.Fatalf("Square() bounds = %v, want 4x4", dst.Bounds())
+	}
+	if c := dst.RGBAAt(0, 0); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Fatalf("Square() corner = %v, want white center of source", c)
+	}
+}
@@ -0,0 +1,144 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// exifJPEG returns JPEG with EXIF orientation in given byte order
+func exifJPEG(t *testing.T, order binary.ByteOrder, orientation uint16) []byte {
+	t.Helper()
+
+	var img bytes.Buffer
+	if err := jpeg.Encode(&img, image.NewRGBA(image.Rect(0, 0, 2, 2)), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+
+	// TIFF header, IFD0 with single orientation entry and no next IFD
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], exifShortType)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	// APP1 goes right after SOI
+	data := append([]byte{}, img.Bytes()[:2]...)
+	data = append(data, app1...)
+	data = append(data, segment...)
+	return append(data, img.Bytes()[2:]...)
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for orientation := uint16(1); orientation <= 8; orientation++ {
+			data := exifJPEG(t, order, orientation)
+			if got := jpegOrientation(data); got != int(orientation) {
+				t.Errorf("jpegOrientation() %s = %d, want %d", order, got, orientation)
+			}
+
+			// Decode reports orientation of JPEG
+			if _, got, err := Decode(data, 100); err != nil || got != int(orientation) {
+				t.Errorf("Decode() orientation = %d, %v, want %d", got, err, orientation)
+			}
+		}
+	}
+}
+
+func TestJPEGOrientationDefaults(t *testing.T) {
+	var plain bytes.Buffer
+	if err := jpeg.Encode(&plain, image.NewRGBA(image.Rect(0, 0, 2, 2)), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+
+	invalid := exifJPEG(t, binary.LittleEndian, 9)
+
+	truncated := exifJPEG(t, binary.BigEndian, 6)
+	truncated = truncated[:30]
+
+	tests := map[string][]byte{
+		"no exif":       plain.Bytes(),
+		"out of range":  invalid,
+		"truncated":     truncated,
+		"not jpeg":      []byte("\x89PNG\r\n\x1a\n"),
+		"empty":         nil,
+		"bad segment":   {0xFF, 0xD8, 0x00, 0x00},
+		"short segment": {0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01},
+	}
+
+	for name, data := range tests {
+		if got := jpegOrientation(data); got != 1 {
+			t.Errorf("jpegOrientation() of %s = %d, want 1", name, got)
+		}
+	}
+}
+
+// corner is stored pixel of 3x2 test image shown at corner of oriented one
+type corner struct{ x, y int }
+
+func TestOrient(t *testing.T) {
+	const w, h = 3, 2
+
+	// Pixel color encodes its stored position
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, color.RGBA{uint8(x), uint8(y), 1, 255})
+		}
+	}
+
+	tests := []struct {
+		orientation int
+		topLeft     corner
+		topRight    corner
+	}{
+		{1, corner{0, 0}, corner{w - 1, 0}},
+		{2, corner{w - 1, 0}, corner{0, 0}},
+		{3, corner{w - 1, h - 1}, corner{0, h - 1}},
+		{4, corner{0, h - 1}, corner{w - 1, h - 1}},
+		{5, corner{0, 0}, corner{0, h - 1}},
+		{6, corner{0, h - 1}, corner{0, 0}},
+		{7, corner{w - 1, h - 1}, corner{w - 1, 0}},
+		{8, corner{w - 1, 0}, corner{w - 1, h - 1}},
+	}
+
+	for _, tt := range tests {
+		dst := Orient(img, tt.orientation)
+
+		dw, dh := w, h
+		if tt.orientation >= 5 {
+			dw, dh = h, w
+		}
+		if dst.Bounds().Dx() != dw || dst.Bounds().Dy() != dh {
+			t.Errorf("Orient(%d) bounds = %v, want %dx%d", tt.orientation, dst.Bounds(), dw, dh)
+			continue
+		}
+
+		for _, c := range []struct {
+			name string
+			x    int
+			want corner
+		}{{"top left", 0, tt.topLeft}, {"top right", dw - 1, tt.topRight}} {
+			got := dst.RGBAAt(c.x, 0)
+			if int(got.R) != c.want.x || int(got.G) != c.want.y {
+				t.Errorf("Orient(%d) %s = stored (%d, %d), want (%d, %d)",
+					tt.orientation, c.name, got.R, got.G, c.want.x, c.want.y)
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit describes token bucket: refill rate and bucket capacity
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// Result of a single Allow call
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is time until bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is time until next request is allowed, zero if allowed
+	RetryAfter time.Duration
+}
+
+// Limiter is implemented by rate limit stores.
+// In-memory store is used by default, shared store can be plugged later.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// MemoryLimiter keeps token buckets in process memory
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	idleTTL   time.Duration
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryLimiter creates in-memory limiter, buckets unused for idleTTL are dropped
+func NewMemoryLimiter(idleTTL time.Duration) *MemoryLimiter {
+	if idleTTL <= 0 {
+		idleTTL = 10 * time.Minute
+	}
+
+	return &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		idleTTL: idleTTL,
+		now:     time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	// Refill tokens for elapsed time
+	elapsed := now.Sub(b.lastSeen).Seconds()
+	b.tokens = math.Min(burst, b.tokens+elapsed*limit.RequestsPerSecond)
+	b.lastSeen = now
+
+	result := Result{Limit: int(burst)}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / limit.RequestsPerSecond)
+	}
+
+	result.Remaining = int(math.Floor(b.tokens))
+	result.ResetAfter = secondsToDuration((burst - b.tokens) / limit.RequestsPerSecond)
+
+	return result, nil
+}
+
+// sweep drops idle buckets, runs at most once per idleTTL
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idleTTL {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > l.idleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(s float64) time.Duration {
+	if math.IsInf(s, 0) || math.IsNaN(s) {
+		return 0
+	}
+
+	return time.Duration(s * float64(time.Second))
+}
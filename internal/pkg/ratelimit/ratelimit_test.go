@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns limiter with clock moved by advance
+func newTestLimiter() (*MemoryLimiter, func(time.Duration)) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewMemoryLimiter(time.Minute)
+	l.now = func() time.Time { return now }
+
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func allow(t *testing.T, l *MemoryLimiter, key string, limit Limit) Result {
+	t.Helper()
+
+	result, err := l.Allow(context.Background(), key, limit)
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+
+	return result
+}
+
+func TestMemoryLimiterBurst(t *testing.T) {
+	l, _ := newTestLimiter()
+	limit := Limit{RequestsPerSecond: 1, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		result := allow(t, l, "ip:1", limit)
+		if !result.Allowed || result.Remaining != i || result.Limit != 3 {
+			t.Fatalf("Allow() = %+v, want allowed with %d remaining", result, i)
+		}
+	}
+
+	result := allow(t, l, "ip:1", limit)
+	if result.Allowed || result.RetryAfter != time.Second || result.ResetAfter != 3*time.Second {
+		t.Fatalf("Allow() = %+v, want denied, retry after 1s, reset after 3s", result)
+	}
+
+	// Other keys have own buckets
+	if result = allow(t, l, "ip:2", limit); !result.Allowed {
+		t.Fatalf("Allow() of other key = %+v, want allowed", result)
+	}
+}
+
+func TestMemoryLimiterRefill(t *testing.T) {
+	l, advance := newTestLimiter()
+	limit := Limit{RequestsPerSecond: 2, Burst: 2}
+
+	allow(t, l, "ip:1", limit)
+	allow(t, l, "ip:1", limit)
+
+	result := allow(t, l, "ip:1", limit)
+	if result.Allowed || result.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("Allow() = %+v, want denied, retry after 500ms", result)
+	}
+
+	advance(250 * time.Millisecond)
+	if result = allow(t, l, "ip:1", limit); result.Allowed || result.RetryAfter != 250*time.Millisecond {
+		t.Fatalf("Allow() = %+v, want denied, retry after 250ms", result)
+	}
+
+	advance(250 * time.Millisecond)
+	if result = allow(t, l, "ip:1", limit); !result.Allowed {
+		t.Fatalf("Allow() after refill = %+v, want allowed", result)
+	}
+
+	// Bucket does not fill above burst
+	advance(time.Hour)
+	for range 2 {
+		allow(t, l, "ip:1", limit)
+	}
+	if result = allow(t, l, "ip:1", limit); result.Allowed {
+		t.Fatalf("Allow() = %+v, want denied after burst", result)
+	}
+}
+
+func TestMemoryLimiterDropsIdleBuckets(t *testing.T) {
+	l, advance := newTestLimiter()
+	limit := Limit{RequestsPerSecond: 1, Burst: 1}
+
+	allow(t, l, "ip:1", limit)
+	advance(2 * time.Minute)
+	allow(t, l, "ip:2", limit)
+
+	if _, ok := l.buckets["ip:1"]; ok {
+		t.Fatal("idle bucket is kept")
+	}
+}
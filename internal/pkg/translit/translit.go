@@ -0,0 +1,59 @@
+// Package translit transliterates Cyrillic into Latin
+package translit
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// gost is GOST 7.79-2000 system B, ASCII form of ISO 9, for lower case letters
+var gost = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "x", 'ц': "cz", 'ч': "ch", 'ш': "sh", 'щ': "shh", 'ъ': "``",
+	'ы': "y`", 'ь': "`", 'э': "e`", 'ю': "yu", 'я': "ya",
+	// Ukrainian and Belarusian letters
+	'і': "i`", 'ї': "yi", 'є': "ye", 'ґ': "g`", 'ў': "u`",
+}
+
+// GOST transliterates s by GOST 7.79-2000 system B, non-Cyrillic runes are kept
+func GOST(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for i, r := range s {
+		lower := unicode.ToLower(r)
+
+		latin, ok := gost[lower]
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+
+		// Ц is "c" before i, e, y and j
+		if lower == 'ц' {
+			next, _ := utf8.DecodeRuneInString(s[i+utf8.RuneLen(r):])
+			if softensC(next) {
+				latin = "c"
+			}
+		}
+
+		if lower != r {
+			latin = strings.ToUpper(latin[:1]) + latin[1:]
+		}
+		b.WriteString(latin)
+	}
+
+	return b.String()
+}
+
+func softensC(next rune) bool {
+	next = unicode.ToLower(next)
+	if latin, ok := gost[next]; ok {
+		next = rune(latin[0])
+	}
+
+	return strings.ContainsRune("eiyj", next)
+}
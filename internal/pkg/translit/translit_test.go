@@ -0,0 +1,39 @@
+package translit
+
+import "testing"
+
+func TestGOST(t *testing.T) {
+	tests := map[string]string{
+		"иванов": "ivanov",
+		"Щукин":  "Shhukin",
+		"Ёлкин":  "Yolkin",
+		"Жанна":  "Zhanna",
+		"Хохлов": "Xoxlov",
+		"Юлия":   "Yuliya",
+		// Ц is "c" before е, и, ы, й and their Latin forms, "cz" elsewhere
+		"Цезарь":  "Cezar`",
+		"цирк":    "cirk",
+		"Цыганов": "Cy`ganov",
+		"цапля":   "czaplya",
+		"конец":   "konecz",
+		"ЦИРК":    "CIRK",
+		"цj":      "cj",
+		// Hard and soft signs, Ukrainian and Belarusian letters
+		"объект": "ob``ekt",
+		"Игорь":  "Igor`",
+		"Їжак":   "Yizhak",
+		"Євген":  "Yevgen",
+		"Ґанок":  "G`anok",
+		"Іван":   "I`van",
+		"Ўладзь": "U`ladz`",
+		// Non-Cyrillic runes are kept
+		"O'Brien":      "O'Brien",
+		"Анна-Мария 2": "Anna-Mariya 2",
+	}
+
+	for in, want := range tests {
+		if got := GOST(in); got != want {
+			t.Errorf("GOST(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package validation
+
+import (
+	"github.com/go-playground/locales"
+	ut "github.com/go-playground/universal-translator"
+)
+
+// catalog is messages of one language,
+// in templates {0} is field name and {1} is tag parameter
+type catalog struct {
+	messages map[string]string
+	// plurals are cardinal forms of units, {0} is number
+	plurals map[string]map[locales.PluralRule]string
+}
+
+func (c catalog) load(trans ut.Translator) error {
+	for key, text := range c.messages {
+		if err := trans.Add(key, text, false); err != nil {
+			return err
+		}
+	}
+
+	for key, forms := range c.plurals {
+		for rule, text := range forms {
+			if err := trans.AddCardinal(key, text, rule, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// catalogs are messages by language
+var catalogs = map[string]catalog{
+	"en": {
+		messages: map[string]string{
+			invalidKey:       "{0} is invalid",
+			"required":       "{0} is required",
+			"min-characters": "{0} must be at least {1} long",
+			"min-items":      "{0} must contain at least {1}",
+			"min-number":     "{0} must be {1} or greater",
+			"max-characters": "{0} must be at most {1} long",
+			"max-items":      "{0} must contain at most {1}",
+			"max-number":     "{0} must be {1} or less",
+			"len-characters": "{0} must be {1} long",
+			"len-items":      "{0} must contain {1}",
+			"len-number":     "{0} must be equal to {1}",
+			"oneof":          "{0} must be one of [{1}]",
+			"url":            "{0} must be a valid URL",
+			"startswith":     "{0} must start with \"{1}\"",
+			"birthdate":      "{0} must not be in the future or more than {1} ago",
+			"timezone":       "{0} must be IANA time zone name, e.g. Europe/Moscow",
+			"gender":         "{0} must be one of allowed genders, see /api/v1/account/genders",
+			"locale":         "{0} must be BCP 47 language tag, e.g. ru-RU",
+			"country":        "{0} must be ISO 3166-1 alpha-2 country code, e.g. RU",
+			"email":          "{0} must be a valid email address",
+			"phone":          "{0} must be international phone number, e.g. +79991234567",
+			"numeric":        "{0} must contain digits only",
+		},
+		plurals: map[string]map[locales.PluralRule]string{
+			"characters": {
+				locales.PluralRuleOne:   "{0} character",
+				locales.PluralRuleOther: "{0} characters",
+			},
+			"items": {
+				locales.PluralRuleOne:   "{0} item",
+				locales.PluralRuleOther: "{0} items",
+			},
+			"years": {
+				locales.PluralRuleOne:   "{0} year",
+				locales.PluralRuleOther: "{0} years",
+			},
+		},
+	},
+	"ru": {
+		messages: map[string]string{
+			invalidKey:       "поле {0} заполнено неверно",
+			"required":       "поле {0} обязательно",
+			"min-characters": "поле {0} должно содержать не менее {1}",
+			"min-items":      "поле {0} должно содержать не менее {1}",
+			"min-number":     "поле {0} должно быть не меньше {1}",
+			"max-characters": "поле {0} должно содержать не более {1}",
+			"max-items":      "поле {0} должно содержать не более {1}",
+			"max-number":     "поле {0} должно быть не больше {1}",
+			"len-characters": "поле {0} должно содержать ровно {1}",
+			"len-items":      "поле {0} должно содержать ровно {1}",
+			"len-number":     "поле {0} должно быть равно {1}",
+			"oneof":          "поле {0} должно быть одним из [{1}]",
+			"url":            "поле {0} должно быть корректным URL",
+			"startswith":     "поле {0} должно начинаться с \"{1}\"",
+			"birthdate":      "поле {0} не должно быть в будущем или более {1} назад",
+			"timezone":       "поле {0} должно быть часовым поясом IANA, например Europe/Moscow",
+			"gender":         "поле {0} должно быть одним из допустимых значений пола, см. /api/v1/account/genders",
+			"locale":         "поле {0} должно быть языковым тегом BCP 47, например ru-RU",
+			"country":        "поле {0} должно быть кодом страны ISO 3166-1 alpha-2, например RU",
+			"email":          "поле {0} должно быть корректным адресом электронной почты",
+			"phone":          "поле {0} должно быть международным номером телефона, например +79991234567",
+			"numeric":        "поле {0} должно содержать только цифры",
+		},
+		plurals: map[string]map[locales.PluralRule]string{
+			"characters": {
+				locales.PluralRuleOne:   "{0} символ",
+				locales.PluralRuleFew:   "{0} символа",
+				locales.PluralRuleMany:  "{0} символов",
+				locales.PluralRuleOther: "{0} символа",
+			},
+			"items": {
+				locales.PluralRuleOne:   "{0} элемент",
+				locales.PluralRuleFew:   "{0} элемента",
+				locales.PluralRuleMany:  "{0} элементов",
+				locales.PluralRuleOther: "{0} элемента",
+			},
+			"years": {
+				locales.PluralRuleOne:   "{0} год",
+				locales.PluralRuleFew:   "{0} года",
+				locales.PluralRuleMany:  "{0} лет",
+				locales.PluralRuleOther: "{0} года",
+			},
+		},
+	},
+}
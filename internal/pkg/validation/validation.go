@@ -0,0 +1,171 @@
+package validation
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator"
+)
+
+// DefaultLanguage is used when requested language has no messages
+const DefaultLanguage = "en"
+
+// invalidKey is message of tags without translation
+const invalidKey = "invalid"
+
+var uni = ut.New(en.New(), en.New(), ru.New())
+
+// translations build messages of validation tags in any language
+var translations = map[string]validator.TranslationFunc{
+	"required":   simple("required"),
+	"min":        length("min"),
+	"max":        length("max"),
+	"len":        length("len"),
+	"oneof":      withParam("oneof"),
+	"url":        simple("url"),
+	"startswith": withParam("startswith"),
+	"birthdate":  birthdate,
+	"timezone":   simple("timezone"),
+	"gender":     simple("gender"),
+	"locale":     simple("locale"),
+	"country":    simple("country"),
+	"email":      simple("email"),
+	"phone":      simple("phone"),
+	"numeric":    simple("numeric"),
+}
+
+func init() {
+	for lang, c := range catalogs {
+		trans, found := uni.GetTranslator(lang)
+		if !found {
+			panic("validation: no " + lang + " locale in translator")
+		}
+		if err := c.load(trans); err != nil {
+			panic("validation: failed to load " + lang + " messages: " + err.Error())
+		}
+	}
+}
+
+// Translator returns translator of language, default one if language is not supported
+func Translator(lang string) ut.Translator {
+	trans, _ := uni.FindTranslator(lang, DefaultLanguage)
+	return trans
+}
+
+// RegisterTranslations registers messages of every known tag in all languages.
+// Translators are shared, so it must be called for every validator instance.
+func RegisterTranslations(v *validator.Validate) error {
+	for lang := range catalogs {
+		trans := Translator(lang)
+		for tag, fn := range translations {
+			err := v.RegisterTranslation(tag, trans, noRegister, fn)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Errors returns per-field messages of validation errors in language
+func Errors(errs validator.ValidationErrors, lang string) []dtos.ValidationError {
+	trans := Translator(lang)
+
+	result := make([]dtos.ValidationError, 0, len(errs))
+	for _, fe := range errs {
+		result = append(result, dtos.ValidationError{
+			Field:   fe.Field(),
+			Tag:     fe.Tag(),
+			Message: Message(fe, trans),
+		})
+	}
+
+	return result
+}
+
+// Message returns message of field error, tags without translation get generic one
+func Message(fe validator.FieldError, trans ut.Translator) string {
+	if _, ok := translations[fe.Tag()]; ok {
+		return fe.Translate(trans)
+	}
+
+	return invalid(trans, fe)
+}
+
+// noRegister is used as messages are loaded into translators once in init
+func noRegister(ut.Translator) error {
+	return nil
+}
+
+func simple(key string) validator.TranslationFunc {
+	return func(trans ut.Translator, fe validator.FieldError) string {
+		return translate(trans, fe, key, fe.Field())
+	}
+}
+
+func withParam(key string) validator.TranslationFunc {
+	return func(trans ut.Translator, fe validator.FieldError) string {
+		return translate(trans, fe, key, fe.Field(), fe.Param())
+	}
+}
+
+// length translates min, max and len, which check string length,
+// number of items or value depending on field kind
+func length(tag string) validator.TranslationFunc {
+	return func(trans ut.Translator, fe validator.FieldError) string {
+		var unit string
+		switch fe.Kind() {
+		case reflect.String:
+			unit = "characters"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			unit = "items"
+		default:
+			return translate(trans, fe, tag+"-number", fe.Field(), fe.Param())
+		}
+
+		n, err := strconv.ParseFloat(fe.Param(), 64)
+		if err != nil {
+			return invalid(trans, fe)
+		}
+
+		amount, err := trans.C(unit, n, 0, fe.Param())
+		if err != nil {
+			return invalid(trans, fe)
+		}
+
+		return translate(trans, fe, tag+"-"+unit, fe.Field(), amount)
+	}
+}
+
+func birthdate(trans ut.Translator, fe validator.FieldError) string {
+	years, err := trans.C("years", domain.MaxAge, 0, strconv.Itoa(domain.MaxAge))
+	if err != nil {
+		return invalid(trans, fe)
+	}
+
+	return translate(trans, fe, "birthdate", fe.Field(), years)
+}
+
+func translate(trans ut.Translator, fe validator.FieldError, key string, params ...string) string {
+	msg, err := trans.T(key, params...)
+	if err != nil {
+		return invalid(trans, fe)
+	}
+
+	return msg
+}
+
+func invalid(trans ut.Translator, fe validator.FieldError) string {
+	msg, err := trans.T(invalidKey, fe.Field())
+	if err != nil {
+		return fe.Field() + " failed on " + fe.Tag()
+	}
+
+	return msg
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/go-playground/validator"
+)
+
+// invalidSample fails every translated tag once
+type invalidSample struct {
+	Required   string   `validate:"required"`
+	MinString  string   `validate:"min=3"`
+	MinItems   []string `validate:"min=2"`
+	MinNumber  int      `validate:"min=1"`
+	MaxString  string   `validate:"max=1"`
+	MaxItems   []string `validate:"max=1"`
+	MaxNumber  int      `validate:"max=1"`
+	LenString  string   `validate:"len=2"`
+	LenItems   []string `validate:"len=1"`
+	LenNumber  int      `validate:"len=2"`
+	OneOf      string   `validate:"oneof=a b"`
+	URL        string   `validate:"url"`
+	StartsWith string   `validate:"startswith=https"`
+	Email      string   `validate:"email"`
+	Numeric    string   `validate:"numeric"`
+	Birthdate  string   `validate:"birthdate"`
+	Timezone   string   `validate:"timezone"`
+	Gender     string   `validate:"gender"`
+	Locale     string   `validate:"locale"`
+	Country    string   `validate:"country"`
+	Phone      string   `validate:"phone"`
+}
+
+func newTestValidator(t *testing.T) *validator.Validate {
+	t.Helper()
+
+	v := validator.New()
+	// Service validations are registered by New, here they always fail
+	for _, tag := range []string{"birthdate", "timezone", "gender", "locale", "country", "phone"} {
+		if err := v.RegisterValidation(tag, func(validator.FieldLevel) bool { return false }); err != nil {
+			t.Fatalf("RegisterValidation(%s) error = %v", tag, err)
+		}
+	}
+	if err := RegisterTranslations(v); err != nil {
+		t.Fatalf("RegisterTranslations() error = %v", err)
+	}
+
+	return v
+}
+
+func TestEveryTagHasMessages(t *testing.T) {
+	v := newTestValidator(t)
+
+	err := v.Struct(invalidSample{
+		MinItems:   []string{"a"},
+		MaxString:  "ab",
+		MaxItems:   []string{"a", "b"},
+		MaxNumber:  2,
+		LenString:  "a",
+		LenNumber:  1,
+		OneOf:      "c",
+		URL:        "x",
+		StartsWith: "http",
+		Email:      "x",
+		Numeric:    "x",
+		Birthdate:  "x",
+		Timezone:   "x",
+		Gender:     "x",
+		Locale:     "x",
+		Country:    "x",
+		Phone:      "x",
+	})
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Struct() error = %v, want validation errors", err)
+	}
+	if len(errs) != reflect.TypeOf(invalidSample{}).NumField() {
+		t.Fatalf("Struct() failed %d fields, want every field", len(errs))
+	}
+
+	covered := map[string]bool{}
+	for _, fe := range errs {
+		covered[fe.Tag()] = true
+
+		en := Message(fe, Translator("en"))
+		ru := Message(fe, Translator("ru"))
+		for lang, msg := range map[string]string{"en": en, "ru": ru} {
+			if msg == invalid(Translator(lang), fe) || strings.ContainsAny(msg, "{}") {
+				t.Errorf("%s message of %s = %q, want translated one", lang, fe.Field(), msg)
+			}
+		}
+		if en == ru {
+			t.Errorf("ru message of %s = %q, same as en", fe.Field(), ru)
+		}
+	}
+
+	for tag := range translations {
+		if !covered[tag] {
+			t.Errorf("tag %s is not checked by test", tag)
+		}
+	}
+}
+
+func TestRequestTagsAreTranslated(t *testing.T) {
+	requests := []any{
+		dtos.CreateAccountRequest{},
+		dtos.UpdateAccountRequest{},
+		dtos.SearchAccountsRequest{},
+		dtos.FuzzySearchAccountsRequest{},
+		dtos.BirthdaysRequest{},
+		dtos.SendVerificationRequest{},
+		dtos.ConfirmVerificationRequest{},
+		dtos.ExportAccountsRequest{},
+		dtos.CreateWebhookRequest{},
+		dtos.ListWebhookDeliveriesRequest{},
+	}
+	// Tags that only control validation never fail themselves
+	structural := []string{"omitempty", "dive"}
+
+	for _, request := range requests {
+		typ := reflect.TypeOf(request)
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			for _, rule := range strings.FieldsFunc(field.Tag.Get("validate"), func(r rune) bool { return r == ',' || r == '|' }) {
+				tag, _, _ := strings.Cut(rule, "=")
+				if _, ok := translations[tag]; !ok && !slices.Contains(structural, tag) {
+					t.Errorf("%s.%s: tag %s has no messages", typ.Name(), field.Name, tag)
+				}
+			}
+		}
+	}
+}
+
+func TestLengthMessagesUsePluralForms(t *testing.T) {
+	v := newTestValidator(t)
+
+	tests := map[string]string{"1": "1 символ", "3": "3 символа", "5": "5 символов", "21": "21 символ"}
+
+	for min, want := range tests {
+		var errs validator.ValidationErrors
+		if err := v.Var("", "min="+min); !errors.As(err, &errs) {
+			t.Fatalf("Var() error = %v, want validation errors", err)
+		}
+
+		if msg := Message(errs[0], Translator("ru")); !strings.HasSuffix(msg, "не менее "+want) {
+			t.Errorf("min=%s message = %q, want ending with %q", min, msg, want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/go-playground/validator"
+	"golang.org/x/text/language"
+)
+
+// New returns validator aware of service types and tags
+func New() *validator.Validate {
+	v := validator.New()
+
+	// Errors name fields as clients send them
+	v.RegisterTagNameFunc(jsonFieldName)
+
+	// Date is validated as time.Time
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if date, ok := field.Interface().(dtos.Date); ok {
+			return date.Time()
+		}
+		return nil
+	}, dtos.Date{})
+
+	v.RegisterValidation("birthdate", validateBirthdate)
+	v.RegisterValidation("timezone", validateTimezone)
+	v.RegisterValidation("locale", validateLocale)
+	v.RegisterValidation("country", validateCountry)
+	v.RegisterValidation("phone", validatePhone)
+
+	// Messages are static, failure means they are broken in code
+	if err := RegisterTranslations(v); err != nil {
+		panic("validation: failed to register validation messages: " + err.Error())
+	}
+
+	return v
+}
+
+// jsonFieldName returns json name of field, Go name if it has none
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
+
+// validateBirthdate rejects dates in the future and implausible ages
+func validateBirthdate(fl validator.FieldLevel) bool {
+	birthdate, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+
+	today := dtos.DateOf(time.Now()).Time()
+
+	return !birthdate.After(today) && !birthdate.Before(today.AddDate(-domain.MaxAge, 0, 0))
+}
+
+// validateTimezone accepts IANA time zone names
+func validateTimezone(fl validator.FieldLevel) bool {
+	timezone := fl.Field().String()
+	if timezone == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
+
+// NewAccountValidator returns validator with gender tag accepting
+// codes and aliases of allowed genders
+func NewAccountValidator(genders domain.Genders) *validator.Validate {
+	v := New()
+	v.RegisterValidation("gender", genderValidator(genders))
+
+	return v
+}
+
+// genderValidator accepts codes and aliases of allowed genders
+func genderValidator(genders domain.Genders) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		_, err := genders.Parse(fl.Field().String())
+		return err == nil
+	}
+}
+
+// validateLocale accepts BCP 47 language tags, "_" separator is allowed
+func validateLocale(fl validator.FieldLevel) bool {
+	_, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(fl.Field().String()), "_", "-"))
+	return err == nil
+}
+
+// validateCountry accepts ISO 3166-1 alpha-2 codes in any case
+func validateCountry(fl validator.FieldLevel) bool {
+	return domain.IsCountryCode(strings.TrimSpace(fl.Field().String()))
+}
+
+// validatePhone accepts international numbers with formatting, e.g. "+7 (999) 123-45-67"
+func validatePhone(fl validator.FieldLevel) bool {
+	return domain.IsPhone(domain.NormalizePhone(fl.Field().String()))
+}
@@ -3,11 +3,15 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
-	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/events"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type AccountRepository struct {
@@ -20,36 +24,129 @@ func NewAccountRepository(db *sqlx.DB) *AccountRepository {
 	}
 }
 
+// accountColumns are selected into Account, nullable columns are coalesced
+const accountColumns = `user_id, firstname, surname, COALESCE(patronymic, '') AS patronymic,
+	COALESCE(gender, '') AS gender, birthdate, COALESCE(display_name, '') AS display_name,
+	COALESCE(bio, '') AS bio, COALESCE(locale, '') AS locale, COALESCE(timezone, '') AS timezone,
+	COALESCE(country, '') AS country, COALESCE(city, '') AS city, COALESCE(email, '') AS email,
+	email_verified_at, COALESCE(phone, '') AS phone, phone_verified_at, avatar, created_at, updated_at`
+
+// profileColumns are optional columns, empty values are stored as NULL.
+// Contacts are inserted unverified.
+var profileColumns = []string{"display_name", "bio", "locale", "timezone", "country", "city", "email", "phone"}
+
+// birthdayKey is expression of idx_accounts_birthday, see domain.BirthdayKey.
+// CAST is used as named queries treat "::" as escaped colon.
+const birthdayKey = `CAST(EXTRACT(MONTH FROM birthdate) * 100 + EXTRACT(DAY FROM birthdate) AS int)`
+
 // Database inner structure
 type Account struct {
-	UserId     uuid.UUID `db:"user_id"`
-	Firstname  string    `db:"firstname"`
-	Surname    string    `db:"surname"`
-	Patronymic string    `db:"patronymic"`
-	Gender     string    `db:"gender"`
-	Birthdate  time.Time `db:"birthdate"`
-}
+	UserId     uuid.UUID  `db:"user_id"`
+	Firstname  string     `db:"firstname"`
+	Surname    string     `db:"surname"`
+	Patronymic string     `db:"patronymic"`
+	Gender     string     `db:"gender"`
+	Birthdate  *time.Time `db:"birthdate"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
 
-func newAccount(a dtos.CreateAccountRequest) Account {
-	// "02-01-2006" is just format string
-	birthdate, _ := time.Parse("02-01-2006", a.Birthdate)
+	DisplayName string `db:"display_name"`
+	Bio         string `db:"bio"`
+	Locale      string `db:"locale"`
+	Timezone    string `db:"timezone"`
+	Country     string `db:"country"`
+	City        string `db:"city"`
 
+	Email           string     `db:"email"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+	Phone           string     `db:"phone"`
+	PhoneVerifiedAt *time.Time `db:"phone_verified_at"`
+
+	// Avatar is JSON of avatarData, NULL if not uploaded
+	Avatar []byte `db:"avatar"`
+
+	// Search keys are written only, see domain.NameKey
+	FirstnameKey  string `db:"firstname_key"`
+	SurnameKey    string `db:"surname_key"`
+	PatronymicKey string `db:"patronymic_key"`
+}
+
+func newAccount(a domain.Account) Account {
 	return Account{
 		UserId:     a.UserId,
 		Firstname:  a.Firstname,
 		Surname:    a.Surname,
 		Patronymic: a.Patronymic,
 		Gender:     a.Gender,
-		Birthdate:  birthdate,
+		Birthdate:  nullTime(a.Birthdate),
+		CreatedAt:  a.CreatedAt,
+		UpdatedAt:  a.UpdatedAt,
+
+		DisplayName: a.DisplayName,
+		Bio:         a.Bio,
+		Locale:      a.Locale,
+		Timezone:    a.Timezone,
+		Country:     a.Country,
+		City:        a.City,
+
+		Email:           a.Email.Value,
+		EmailVerifiedAt: a.Email.VerifiedAt,
+		Phone:           a.Phone.Value,
+		PhoneVerifiedAt: a.Phone.VerifiedAt,
+
+		FirstnameKey:  domain.NameKey(a.Firstname),
+		SurnameKey:    domain.NameKey(a.Surname),
+		PatronymicKey: domain.NameKey(a.Patronymic),
+	}
+}
+
+func (a Account) domain() domain.Account {
+	account := domain.Account{
+		UserId:     a.UserId,
+		Firstname:  a.Firstname,
+		Surname:    a.Surname,
+		Patronymic: a.Patronymic,
+		Gender:     a.Gender,
+		Profile: domain.Profile{
+			DisplayName: a.DisplayName,
+			Bio:         a.Bio,
+			Locale:      a.Locale,
+			Timezone:    a.Timezone,
+			Country:     a.Country,
+			City:        a.City,
+		},
+		Contacts: domain.Contacts{
+			Email: domain.Contact{Value: a.Email, VerifiedAt: a.EmailVerifiedAt},
+			Phone: domain.Contact{Value: a.Phone, VerifiedAt: a.PhoneVerifiedAt},
+		},
+		Avatar:    decodeAvatar(a.Avatar),
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
 	}
+
+	// DATE column is read at midnight in session time zone
+	if a.Birthdate != nil {
+		account.Birthdate = time.Date(a.Birthdate.Year(), a.Birthdate.Month(), a.Birthdate.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return account
 }
 
-func (r *AccountRepository) checkExistence(ctx context.Context, id uuid.UUID) (bool, error) {
-	var exists bool
+// nullTime maps zero time to NULL
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
 
+func (r *AccountRepository) checkExistence(ctx context.Context, id uuid.UUID) (exists bool, err error) {
 	query := `SELECT EXISTS(SELECT 1 FROM accounts WHERE user_id = $1)`
 
-	err := r.db.GetContext(ctx, &exists, query, id)
+	ctx, span := startSpan(ctx, "AccountRepository.checkExistence", query)
+	defer func() { endSpan(span, err) }()
+
+	err = r.db.GetContext(ctx, &exists, query, id)
 	if err != nil {
 		return false, errors.New("error checking account existence: " + err.Error())
 	}
@@ -57,11 +154,11 @@ func (r *AccountRepository) checkExistence(ctx context.Context, id uuid.UUID) (b
 	return exists, nil
 }
 
-func (r *AccountRepository) Select(ctx context.Context, userId uuid.UUID) (*dtos.GetAccountResponse, error) {
-	query := `
-		SELECT user_id, firstname, surname, patronymic, gender, birthdate
-	 	FROM accounts WHERE user_id = :user_id
-	`
+func (r *AccountRepository) Select(ctx context.Context, userId uuid.UUID) (_ *domain.Account, err error) {
+	query := `SELECT ` + accountColumns + ` FROM accounts WHERE user_id = :user_id`
+
+	ctx, span := startSpan(ctx, "AccountRepository.Select", query)
+	defer func() { endSpan(span, err) }()
 
 	params := map[string]any{"user_id": userId}
 
@@ -73,7 +170,7 @@ func (r *AccountRepository) Select(ctx context.Context, userId uuid.UUID) (*dtos
 
 	// Check if there are any rows
 	if !rows.Next() {
-		return nil, errors.New("no account with such id")
+		return nil, domain.ErrAccountNotFound
 	}
 
 	// Process row
@@ -83,26 +180,37 @@ func (r *AccountRepository) Select(ctx context.Context, userId uuid.UUID) (*dtos
 		return nil, errors.New("failed to get account: " + err.Error())
 	}
 
-	response := &dtos.GetAccountResponse{
-		UserId:     account.UserId,
-		Firstname:  account.Firstname,
-		Surname:    account.Surname,
-		Patronymic: account.Patronymic,
-		Gender:     account.Gender,
-		Birthdate:  account.Birthdate,
-	}
-
-	return response, nil
+	result := account.domain()
+	return &result, nil
 }
 
-func (r *AccountRepository) Insert(ctx context.Context, a dtos.CreateAccountRequest) error {
+func (r *AccountRepository) Insert(ctx context.Context, a domain.Account) (err error) {
+	query := fmt.Sprintf(`
+		INSERT INTO accounts (
+			user_id,
+			firstname,
+			surname,
+			patronymic,
+			gender,
+			birthdate,
+			firstname_key,
+			surname_key,
+			patronymic_key,
+			%s
+	    ) VALUES (:user_id, :firstname, :surname, :patronymic, :gender, :birthdate,
+			:firstname_key, :surname_key, :patronymic_key, %s)
+	`, strings.Join(profileColumns, ", "), profileValues())
+
+	ctx, span := startSpan(ctx, "AccountRepository.Insert", query)
+	defer func() { endSpan(span, err) }()
+
 	// Check if user account already exists
 	exists, err := r.checkExistence(ctx, a.UserId)
 	if err != nil {
 		return err
 	}
 	if exists {
-		return errors.New("account with this id already exists")
+		return domain.ErrAccountExists
 	}
 
 	account := newAccount(a)
@@ -118,22 +226,122 @@ func (r *AccountRepository) Insert(ctx context.Context, a dtos.CreateAccountRequ
 		}
 	}()
 
-	query := `
-		INSERT INTO accounts (
-			user_id,
-			firstname,
-			surname,
-			patronymic,
-			gender,
-			birthdate
-	    ) VALUES (:user_id, :firstname, :surname, :patronymic, :gender, :birthdate)
-	`
-
 	_, err = tx.NamedExecContext(ctx, query, account)
 	if err != nil {
 		return errors.New("failed to insert account: " + err.Error())
 	}
 
+	// Event is committed together with account
+	if err = insertEvent(ctx, tx, events.AccountCreated, accountPayload(account)); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		return errors.New("failed to commit transaction: " + err.Error())
+	}
+
+	return nil
+}
+
+func (r *AccountRepository) Update(ctx context.Context, a domain.AccountChanges) (err error) {
+	// Build SET clause only from provided fields
+	var columns []string
+	params := map[string]any{"user_id": a.UserId}
+
+	set := func(column string, value any) {
+		columns = append(columns, column+" = :"+column)
+		params[column] = value
+	}
+
+	if a.Firstname != nil {
+		set("firstname", *a.Firstname)
+		set("firstname_key", domain.NameKey(*a.Firstname))
+	}
+	if a.Surname != nil {
+		set("surname", *a.Surname)
+		set("surname_key", domain.NameKey(*a.Surname))
+	}
+	if a.Patronymic != nil {
+		set("patronymic", *a.Patronymic)
+		set("patronymic_key", domain.NameKey(*a.Patronymic))
+	}
+	if a.Gender != nil {
+		set("gender", *a.Gender)
+	}
+	if a.Birthdate != nil {
+		set("birthdate", *a.Birthdate)
+	}
+
+	// Empty profile value unsets field, timezone is reset to UTC.
+	// Values are in profileColumns order.
+	profile := []*string{a.DisplayName, a.Bio, a.Locale, a.Timezone, a.Country, a.City, a.Email, a.Phone}
+	for i, column := range profileColumns {
+		if profile[i] != nil {
+			columns = append(columns, column+" = NULLIF(:"+column+", '')")
+			params[column] = *profile[i]
+		}
+	}
+
+	// Changed contact loses verification, SET expressions see values before update
+	for _, column := range []string{"email", "phone"} {
+		if _, ok := params[column]; ok {
+			columns = append(columns, fmt.Sprintf(
+				"%[1]s_verified_at = CASE WHEN %[1]s IS NOT DISTINCT FROM NULLIF(:%[1]s, '') THEN %[1]s_verified_at END",
+				column))
+		}
+	}
+
+	if a.IsEmpty() {
+		return errors.New("nothing to update")
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE accounts SET %s, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = :user_id
+		RETURNING %s
+	`, strings.Join(columns, ", "), accountColumns)
+
+	ctx, span := startSpan(ctx, "AccountRepository.Update", query)
+	defer func() { endSpan(span, err) }()
+
+	// Start transaction
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.New("failed to begin transaction: " + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	rows, err := sqlx.NamedQueryContext(ctx, tx, query, params)
+	if err != nil {
+		return errors.New("failed to update account: " + err.Error())
+	}
+
+	if !rows.Next() {
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return errors.New("failed to update account: " + err.Error())
+		}
+		err = domain.ErrAccountNotFound
+		return err
+	}
+
+	var account Account
+	err = rows.StructScan(&account)
+	rows.Close()
+	if err != nil {
+		return errors.New("failed to update account: " + err.Error())
+	}
+
+	// Event carries state after update
+	if err = insertEvent(ctx, tx, events.AccountUpdated, accountPayload(account)); err != nil {
+		return err
+	}
+
 	// Commit transaction
 	if err = tx.Commit(); err != nil {
 		return errors.New("failed to commit transaction: " + err.Error())
@@ -141,3 +349,229 @@ func (r *AccountRepository) Insert(ctx context.Context, a dtos.CreateAccountRequ
 
 	return nil
 }
+
+// Delete removes account and returns blob keys of its avatar, files are left to caller
+func (r *AccountRepository) Delete(ctx context.Context, userId uuid.UUID) (avatarKeys []string, err error) {
+	query := `DELETE FROM accounts WHERE user_id = $1 RETURNING avatar`
+
+	ctx, span := startSpan(ctx, "AccountRepository.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	// Start transaction
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.New("failed to begin transaction: " + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var avatars [][]byte
+	if err = tx.SelectContext(ctx, &avatars, query, userId); err != nil {
+		return nil, errors.New("failed to delete account: " + err.Error())
+	}
+	if len(avatars) == 0 {
+		err = domain.ErrAccountNotFound
+		return nil, err
+	}
+
+	// Pending codes have no foreign key, accounts.user_id is not unique
+	_, err = tx.ExecContext(ctx, `DELETE FROM verification_codes WHERE user_id = $1`, userId)
+	if err != nil {
+		return nil, errors.New("failed to delete verification codes: " + err.Error())
+	}
+
+	if err = insertEvent(ctx, tx, events.AccountDeleted, events.AccountPayload{UserId: userId}); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		return nil, errors.New("failed to commit transaction: " + err.Error())
+	}
+
+	for _, data := range avatars {
+		if avatar := decodeAvatar(data); avatar != nil {
+			avatarKeys = append(avatarKeys, avatar.Keys...)
+		}
+	}
+
+	return avatarKeys, nil
+}
+
+func (r *AccountRepository) Search(ctx context.Context, f domain.AccountFilter) (_ []domain.Account, err error) {
+	params := map[string]any{
+		"limit":  f.Limit,
+		"offset": f.Offset,
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM accounts %s
+		ORDER BY surname, firstname, user_id
+		LIMIT :limit OFFSET :offset
+	`, accountColumns, filterWhere(f, params))
+
+	ctx, span := startSpan(ctx, "AccountRepository.Search", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.New("failed to execute query: " + err.Error())
+	}
+	defer rows.Close()
+
+	accounts := []domain.Account{}
+	for rows.Next() {
+		var account Account
+		if err = rows.StructScan(&account); err != nil {
+			return nil, errors.New("failed to get account: " + err.Error())
+		}
+
+		accounts = append(accounts, account.domain())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.New("failed to get accounts: " + err.Error())
+	}
+
+	return accounts, nil
+}
+
+// Birthdays returns accounts which birthdate keys are in keys, ordered as keys
+func (r *AccountRepository) Birthdays(ctx context.Context, keys []int, limit, offset int) (_ []domain.Account, err error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM accounts
+		WHERE %s = ANY(CAST(:keys AS int[]))
+		ORDER BY array_position(CAST(:keys AS int[]), %s), surname, firstname, user_id
+		LIMIT :limit OFFSET :offset
+	`, accountColumns, birthdayKey, birthdayKey)
+
+	ctx, span := startSpan(ctx, "AccountRepository.Birthdays", query)
+	defer func() { endSpan(span, err) }()
+
+	params := map[string]any{
+		"keys":   pq.Array(keys),
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	rows, err := r.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.New("failed to execute query: " + err.Error())
+	}
+	defer rows.Close()
+
+	accounts := []domain.Account{}
+	for rows.Next() {
+		var account Account
+		if err = rows.StructScan(&account); err != nil {
+			return nil, errors.New("failed to get account: " + err.Error())
+		}
+
+		accounts = append(accounts, account.domain())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.New("failed to get accounts: " + err.Error())
+	}
+
+	return accounts, nil
+}
+
+// FuzzySearch finds accounts by full name with typos in either script,
+// ranked by trigram word similarity and full text rank
+func (r *AccountRepository) FuzzySearch(ctx context.Context, name string, limit, offset int) (_ []domain.AccountMatch, err error) {
+	query := `
+		WITH q AS (SELECT :key AS key, plainto_tsquery('simple', :key) AS tsquery)
+		SELECT ` + accountColumns + `,
+			word_similarity(q.key, full_name_key) + ts_rank(search_vector, q.tsquery) AS rank
+		FROM accounts, q
+		WHERE full_name_key %> q.key OR search_vector @@ q.tsquery
+		ORDER BY rank DESC, surname, firstname, user_id
+		LIMIT :limit OFFSET :offset
+	`
+
+	ctx, span := startSpan(ctx, "AccountRepository.FuzzySearch", query)
+	defer func() { endSpan(span, err) }()
+
+	params := map[string]any{
+		"key":    domain.NameKey(name),
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	rows, err := r.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.New("failed to execute query: " + err.Error())
+	}
+	defer rows.Close()
+
+	matches := []domain.AccountMatch{}
+	for rows.Next() {
+		var match struct {
+			Account
+			Rank float64 `db:"rank"`
+		}
+		if err = rows.StructScan(&match); err != nil {
+			return nil, errors.New("failed to get account: " + err.Error())
+		}
+
+		matches = append(matches, domain.AccountMatch{Account: match.Account.domain(), Rank: match.Rank})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.New("failed to get accounts: " + err.Error())
+	}
+
+	return matches, nil
+}
+
+// profileValues returns named params of profileColumns, empty strings are stored as NULL
+func profileValues() string {
+	values := make([]string, 0, len(profileColumns))
+	for _, column := range profileColumns {
+		values = append(values, "NULLIF(:"+column+", '')")
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// filterWhere builds WHERE clause of filter and adds its named params
+func filterWhere(f domain.AccountFilter, params map[string]any) string {
+	var conditions []string
+
+	// Names are matched by search keys, so either script and any case match
+	if f.Surname != "" {
+		// Prefix match can use idx_accounts_surname_key
+		conditions = append(conditions, "surname_key LIKE :surname")
+		params["surname"] = escapeLike(domain.NameKey(f.Surname)) + "%"
+	}
+	if f.Firstname != "" {
+		conditions = append(conditions, "firstname_key = :firstname")
+		params["firstname"] = domain.NameKey(f.Firstname)
+	}
+	if f.Gender != "" {
+		conditions = append(conditions, "gender = :gender")
+		params["gender"] = f.Gender
+	}
+	if f.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= :created_from")
+		params["created_from"] = *f.CreatedFrom
+	}
+	if f.CreatedTo != nil {
+		conditions = append(conditions, "created_at < :created_to")
+		params["created_to"] = *f.CreatedTo
+	}
+
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// escapeLike escapes LIKE wildcards in user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
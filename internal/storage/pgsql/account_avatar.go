@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/events"
+	"github.com/google/uuid"
+)
+
+// Database inner structure of accounts.avatar
+type avatarData struct {
+	ID         uuid.UUID         `json:"id"`
+	Keys       []string          `json:"keys"`
+	URLs       map[string]string `json:"urls"`
+	UploadedAt time.Time         `json:"uploaded_at"`
+}
+
+func encodeAvatar(a *domain.Avatar) ([]byte, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(avatarData{
+		ID:         a.ID,
+		Keys:       a.Keys,
+		URLs:       a.URLs,
+		UploadedAt: a.UploadedAt,
+	})
+}
+
+// decodeAvatar returns nil for NULL column, column is written by service only
+func decodeAvatar(data []byte) *domain.Avatar {
+	if data == nil {
+		return nil
+	}
+
+	var a avatarData
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil
+	}
+
+	return &domain.Avatar{
+		ID:         a.ID,
+		Keys:       a.Keys,
+		URLs:       a.URLs,
+		UploadedAt: a.UploadedAt,
+	}
+}
+
+// SetAvatar replaces account avatar, nil removes it. Previous avatar is
+// returned, so its files can be removed once change is committed.
+func (r *AccountRepository) SetAvatar(ctx context.Context, userId uuid.UUID,
+	avatar *domain.Avatar) (_ *domain.Avatar, err error) {
+	query := fmt.Sprintf(`
+		UPDATE accounts SET avatar = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+		RETURNING %s
+	`, accountColumns)
+
+	ctx, span := startSpan(ctx, "AccountRepository.SetAvatar", query)
+	defer func() { endSpan(span, err) }()
+
+	data, err := encodeAvatar(avatar)
+	if err != nil {
+		return nil, errors.New("failed to marshal avatar: " + err.Error())
+	}
+
+	// Start transaction
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.New("failed to begin transaction: " + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Row lock keeps previous avatar consistent with concurrent uploads
+	var previous []byte
+	err = tx.GetContext(ctx, &previous, `SELECT avatar FROM accounts WHERE user_id = $1 FOR UPDATE`, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = domain.ErrAccountNotFound
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.New("failed to get avatar: " + err.Error())
+	}
+
+	var account Account
+	if err = tx.GetContext(ctx, &account, query, userId, data); err != nil {
+		return nil, errors.New("failed to update avatar: " + err.Error())
+	}
+
+	// Event carries state after update
+	if err = insertEvent(ctx, tx, events.AccountUpdated, accountPayload(account)); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		return nil, errors.New("failed to commit transaction: " + err.Error())
+	}
+
+	return decodeAvatar(previous), nil
+}
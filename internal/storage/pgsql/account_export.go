@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+const exportFetchSize = 1000
+
+// Export walks accounts with server-side cursor, so only one
+// fetch is held in memory, and calls fn for every row
+func (r *AccountRepository) Export(ctx context.Context, f domain.AccountFilter,
+	fn func(domain.Account) error) (err error) {
+	params := map[string]any{}
+
+	query := fmt.Sprintf(`
+		DECLARE accounts_export NO SCROLL CURSOR FOR
+		SELECT %s
+		FROM accounts %s
+		ORDER BY id
+	`, accountColumns, filterWhere(f, params))
+
+	ctx, span := startSpan(ctx, "AccountRepository.Export", query)
+	defer func() { endSpan(span, err) }()
+
+	// Cursor lives only inside transaction, repeatable read gives consistent snapshot
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return errors.New("failed to begin transaction: " + err.Error())
+	}
+	// Read-only transaction, nothing to commit
+	defer tx.Rollback()
+
+	declare, args, err := sqlx.Named(query, params)
+	if err != nil {
+		return errors.New("failed to bind cursor params: " + err.Error())
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(declare), args...); err != nil {
+		return errors.New("failed to declare cursor: " + err.Error())
+	}
+
+	fetch := fmt.Sprintf(`FETCH FORWARD %d FROM accounts_export`, exportFetchSize)
+	for {
+		rows, err := tx.QueryxContext(ctx, fetch)
+		if err != nil {
+			return errors.New("failed to fetch accounts: " + err.Error())
+		}
+
+		fetched := 0
+		for rows.Next() {
+			var account Account
+			if err = rows.StructScan(&account); err != nil {
+				rows.Close()
+				return errors.New("failed to get account: " + err.Error())
+			}
+			fetched++
+
+			err = fn(account.domain())
+			if err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return errors.New("failed to fetch accounts: " + err.Error())
+		}
+		if fetched < exportFetchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/events"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// CopyInsert inserts accounts in a single transaction using COPY.
+// Accounts whose user id already exists are skipped, ids of inserted ones are returned.
+func (r *AccountRepository) CopyInsert(ctx context.Context, accounts []domain.Account) (_ []uuid.UUID, err error) {
+	// Events for inserted accounts are written in the same statement
+	query := `
+		WITH inserted AS (
+			INSERT INTO accounts (user_id, firstname, surname, patronymic, gender, birthdate,
+				firstname_key, surname_key, patronymic_key)
+			SELECT i.user_id, i.firstname, i.surname, i.patronymic, i.gender, i.birthdate,
+				i.firstname_key, i.surname_key, i.patronymic_key
+			FROM accounts_import i
+			WHERE NOT EXISTS (SELECT 1 FROM accounts a WHERE a.user_id = i.user_id)
+			RETURNING user_id, firstname, surname, patronymic, gender, birthdate
+		), outbox_events AS (
+			INSERT INTO outbox (aggregate_id, event_type, payload)
+			SELECT user_id, '` + events.AccountCreated + `', jsonb_strip_nulls(jsonb_build_object(
+				'user_id', user_id,
+				'firstname', firstname,
+				'surname', surname,
+				'patronymic', NULLIF(patronymic, ''),
+				'gender', NULLIF(gender, ''),
+				'birthdate', to_char(birthdate, 'YYYY-MM-DD')
+			))
+			FROM inserted
+		)
+		SELECT user_id FROM inserted
+	`
+
+	ctx, span := startSpan(ctx, "AccountRepository.CopyInsert", query)
+	defer func() { endSpan(span, err) }()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.New("failed to begin transaction: " + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// COPY can't skip conflicting rows, so data goes through temporary table
+	_, err = tx.ExecContext(ctx, `
+		CREATE TEMPORARY TABLE accounts_import (
+			user_id UUID NOT NULL,
+			firstname VARCHAR(255) NOT NULL,
+			surname VARCHAR(255) NOT NULL,
+			patronymic VARCHAR(255),
+			gender VARCHAR(1),
+			birthdate DATE NOT NULL,
+			firstname_key TEXT NOT NULL,
+			surname_key TEXT NOT NULL,
+			patronymic_key TEXT NOT NULL
+		) ON COMMIT DROP
+	`)
+	if err != nil {
+		return nil, errors.New("failed to create import table: " + err.Error())
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("accounts_import",
+		"user_id", "firstname", "surname", "patronymic", "gender", "birthdate",
+		"firstname_key", "surname_key", "patronymic_key",
+	))
+	if err != nil {
+		return nil, errors.New("failed to prepare copy: " + err.Error())
+	}
+
+	for _, a := range accounts {
+		account := newAccount(a)
+
+		_, err = stmt.ExecContext(ctx,
+			account.UserId, account.Firstname, account.Surname,
+			account.Patronymic, account.Gender, account.Birthdate,
+			account.FirstnameKey, account.SurnameKey, account.PatronymicKey,
+		)
+		if err != nil {
+			stmt.Close()
+			return nil, errors.New("failed to copy account: " + err.Error())
+		}
+	}
+
+	// Empty exec flushes buffered rows
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return nil, errors.New("failed to copy accounts: " + err.Error())
+	}
+	if err = stmt.Close(); err != nil {
+		return nil, errors.New("failed to copy accounts: " + err.Error())
+	}
+
+	var inserted []uuid.UUID
+	if err = tx.SelectContext(ctx, &inserted, query); err != nil {
+		return nil, errors.New("failed to insert accounts: " + err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.New("failed to commit transaction: " + err.Error())
+	}
+
+	return inserted, nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+	"github.com/jmoiron/sqlx"
+)
+
+// EventStore keeps processed inbound event ids and dead letters
+type EventStore struct {
+	db *sqlx.DB
+}
+
+func NewEventStore(db *sqlx.DB) *EventStore {
+	return &EventStore{
+		db: db,
+	}
+}
+
+func (s *EventStore) IsProcessed(ctx context.Context, eventId string) (processed bool, err error) {
+	query := `SELECT EXISTS(SELECT 1 FROM processed_events WHERE event_id = $1)`
+
+	ctx, span := startSpan(ctx, "EventStore.IsProcessed", query)
+	defer func() { endSpan(span, err) }()
+
+	err = s.db.GetContext(ctx, &processed, query, eventId)
+	if err != nil {
+		return false, errors.New("failed to check processed event: " + err.Error())
+	}
+
+	return processed, nil
+}
+
+func (s *EventStore) MarkProcessed(ctx context.Context, eventId, eventType string) (err error) {
+	query := `
+		INSERT INTO processed_events (event_id, event_type) VALUES ($1, $2)
+		ON CONFLICT (event_id) DO NOTHING
+	`
+
+	ctx, span := startSpan(ctx, "EventStore.MarkProcessed", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = s.db.ExecContext(ctx, query, eventId, eventType)
+	if err != nil {
+		return errors.New("failed to mark event processed: " + err.Error())
+	}
+
+	return nil
+}
+
+func (s *EventStore) SaveDeadLetter(ctx context.Context, letter events.DeadLetter) (err error) {
+	query := `
+		INSERT INTO dead_letters (event_id, event_type, payload, error, attempts)
+		VALUES (:event_id, :event_type, :payload, :error, :attempts)
+	`
+
+	ctx, span := startSpan(ctx, "EventStore.SaveDeadLetter", query)
+	defer func() { endSpan(span, err) }()
+
+	_, err = s.db.NamedExecContext(ctx, query, letter)
+	if err != nil {
+		return errors.New("failed to save dead letter: " + err.Error())
+	}
+
+	return nil
+}
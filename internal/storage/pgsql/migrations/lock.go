@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+)
+
+// advisoryLockKey is shared by all service instances, so only one
+// of them applies migrations while others wait
+const advisoryLockKey int64 = 0x6163636f756e74 // "account"
+
+const lockPollInterval = 500 * time.Millisecond
+
+// withLock runs fn holding migration advisory lock
+func (m *Migrator) withLock(fn func() error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.lockTimeout)
+	defer cancel()
+
+	conn, err := m.acquireLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer m.releaseLock(conn)
+
+	return fn()
+}
+
+// acquireLock polls pg_try_advisory_lock until lock is taken or ctx expires.
+// Lock is bound to session, so the same connection must be used to release it.
+func (m *Migrator) acquireLock(ctx context.Context) (*sql.Conn, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection for migration lock: %w", err)
+	}
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	waiting := false
+	for {
+		var locked bool
+		err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, advisoryLockKey).Scan(&locked)
+		if err != nil {
+			conn.Close()
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("timed out waiting for migration lock after %s", m.lockTimeout)
+			}
+			return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		if locked {
+			return conn, nil
+		}
+
+		if !waiting {
+			m.logger.Info("waiting for migration lock held by another instance")
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return nil, fmt.Errorf("timed out waiting for migration lock after %s", m.lockTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Migrator) releaseLock(conn *sql.Conn) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, advisoryLockKey)
+	if err != nil {
+		m.logger.Error("failed to release migration lock", slogerr.Error(err))
+	}
+}
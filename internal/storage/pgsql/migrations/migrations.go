@@ -3,54 +3,177 @@ package migrations
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
-	"strings"
+	"time"
 
 	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	embedded "github.com/WebChads/AccountService/migrations"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	_ "github.com/lib/pq"
 )
 
-func RunMigrations(db *sql.DB, logger *slog.Logger) error {
+const defaultLockTimeout = time.Minute
+
+type Options struct {
+	// Dir overrides migrations embedded into the binary
+	Dir string
+	// LockTimeout limits waiting for other instance to finish migrations
+	LockTimeout time.Duration
+}
+
+type Migrator struct {
+	db          *sql.DB
+	source      source.Driver
+	migration   *migrate.Migrate
+	lockTimeout time.Duration
+	logger      *slog.Logger
+}
+
+// NewMigrator creates migrator reading migrations from opts.Dir,
+// migrations embedded into the binary are used if it is empty
+func NewMigrator(db *sql.DB, opts Options, logger *slog.Logger) (*Migrator, error) {
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
 	if err != nil {
 		logger.Error("could not create migration driver", slogerr.Error(err))
-		return err
+		return nil, err
 	}
 
-	migration, err := migrate.NewWithDatabaseInstance(
-		"file://migrations", "postgres", driver,
-	)
+	var src source.Driver
+	if opts.Dir == "" {
+		src, err = iofs.New(embedded.FS, ".")
+	} else {
+		logger.Info("using external migrations directory", "path", opts.Dir)
+		src, err = (&file.File{}).Open("file://" + opts.Dir)
+	}
+	if err != nil {
+		logger.Error("could not open migrations source", slogerr.Error(err))
+		return nil, err
+	}
+
+	migration, err := migrate.NewWithInstance("source", src, "postgres", driver)
 	if err != nil {
 		logger.Error("could not create migration instanse", slogerr.Error(err))
+		return nil, err
+	}
+
+	lockTimeout := opts.LockTimeout
+	if lockTimeout <= 0 {
+		lockTimeout = defaultLockTimeout
+	}
+	migration.LockTimeout = lockTimeout
+
+	return &Migrator{
+		db:          db,
+		source:      src,
+		migration:   migration,
+		lockTimeout: lockTimeout,
+		logger:      logger,
+	}, nil
+}
+
+// RunMigrations applies all available migrations.
+// Migrations use own connection, because closing migrator closes its database.
+func RunMigrations(databaseURL string, opts Options, logger *slog.Logger) error {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		logger.Error("could not open migration database", slogerr.Error(err))
 		return err
 	}
 
-	// Apply available migrations
-	if err := migration.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			logger.Warn("could not apply migrations", slogerr.Error(err))
-			return nil
-		}
+	migrator, err := NewMigrator(db, opts, logger)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	defer migrator.Close()
+
+	return migrator.Up()
+}
+
+// Up applies all available migrations
+func (m *Migrator) Up() error {
+	return m.apply("up", m.migration.Up)
+}
+
+// Down rolls back given number of applied migrations
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("number of steps must be positive, got %d", steps)
+	}
+
+	return m.apply("down", func() error { return m.migration.Steps(-steps) })
+}
+
+// Steps applies (n > 0) or rolls back (n < 0) n migrations
+func (m *Migrator) Steps(n int) error {
+	if n == 0 {
+		return errors.New("number of steps must not be zero")
+	}
+
+	return m.apply("steps", func() error { return m.migration.Steps(n) })
+}
 
-		if strings.Contains(err.Error(), "no such table") {
-			logger.Warn(
-				"first-time migration, creating schema_migrations table",
-				slogerr.Warn(err),
-			)
+// Goto migrates up or down to given version
+func (m *Migrator) Goto(version uint) error {
+	return m.apply("goto", func() error { return m.migration.Migrate(version) })
+}
 
-			if err := migration.Force(1); err != nil {
-				return err
-			}
+// Version returns current schema version and dirty flag
+func (m *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.migration.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
 
-			return migration.Up()
-		}
+	return version, dirty, err
+}
 
-		logger.Error("could not apply migrations", slogerr.Error(err))
+// Force sets schema version without running migrations and clears dirty flag.
+// Should be used only after manual fix of failed migration.
+func (m *Migrator) Force(version int) error {
+	err := m.withLock(func() error { return m.migration.Force(version) })
+	if err != nil {
+		m.logger.Error("could not force migration version", slogerr.Error(err))
 		return err
 	}
 
+	m.logger.Warn("migration version forced", "version", version)
 	return nil
 }
+
+// Close releases migrations source and closes database given to NewMigrator
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.migration.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// apply runs migration operation under advisory lock and reports result
+func (m *Migrator) apply(op string, fn func() error) error {
+	err := m.withLock(fn)
+	if err == nil {
+		version, dirty, _ := m.Version()
+		m.logger.Info("migrations applied", "operation", op, "version", version, "dirty", dirty)
+		return nil
+	}
+
+	if errors.Is(err, migrate.ErrNoChange) {
+		m.logger.Info("no migrations to apply", "operation", op)
+		return nil
+	}
+
+	var dirtyErr migrate.ErrDirty
+	if errors.As(err, &dirtyErr) {
+		err = fmt.Errorf(
+			"database schema is dirty at version %d: fix it manually and run \"migrate force <version>\"",
+			dirtyErr.Version,
+		)
+	}
+
+	m.logger.Error("could not apply migrations", "operation", op, slogerr.Error(err))
+	return err
+}
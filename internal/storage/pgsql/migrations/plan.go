@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// PlannedMigration is a pending up migration
+type PlannedMigration struct {
+	Version    uint
+	Identifier string
+	SQL        string
+}
+
+// Plan lists pending migrations with their SQL without applying anything
+func (m *Migrator) Plan() ([]PlannedMigration, error) {
+	current, dirty, err := m.migration.Version()
+
+	var next uint
+	switch {
+	case errors.Is(err, migrate.ErrNilVersion):
+		next, err = m.source.First()
+	case err != nil:
+		return nil, err
+	case dirty:
+		return nil, fmt.Errorf("database schema is dirty at version %d", current)
+	default:
+		next, err = m.source.Next(current)
+	}
+
+	var plan []PlannedMigration
+	for err == nil {
+		planned, readErr := m.readUp(next)
+		if readErr != nil {
+			return nil, readErr
+		}
+		plan = append(plan, planned)
+
+		next, err = m.source.Next(next)
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (m *Migrator) readUp(version uint) (PlannedMigration, error) {
+	body, identifier, err := m.source.ReadUp(version)
+	if err != nil {
+		return PlannedMigration{}, fmt.Errorf("failed to read migration %d: %w", version, err)
+	}
+	defer body.Close()
+
+	query, err := io.ReadAll(body)
+	if err != nil {
+		return PlannedMigration{}, fmt.Errorf("failed to read migration %d: %w", version, err)
+	}
+
+	return PlannedMigration{
+		Version:    version,
+		Identifier: identifier,
+		SQL:        string(query),
+	}, nil
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// insertEvent writes domain event in caller transaction
+func insertEvent(ctx context.Context, tx *sqlx.Tx, eventType string, payload events.AccountPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.New("failed to marshal event: " + err.Error())
+	}
+
+	query := `INSERT INTO outbox (aggregate_id, event_type, payload) VALUES ($1, $2, $3)`
+
+	_, err = tx.ExecContext(ctx, query, payload.UserId, eventType, data)
+	if err != nil {
+		return errors.New("failed to insert event: " + err.Error())
+	}
+
+	return nil
+}
+
+func accountPayload(a Account) events.AccountPayload {
+	payload := events.AccountPayload{
+		UserId:      a.UserId,
+		Firstname:   a.Firstname,
+		Surname:     a.Surname,
+		Patronymic:  a.Patronymic,
+		Gender:      a.Gender,
+		DisplayName: a.DisplayName,
+		Bio:         a.Bio,
+		Locale:      a.Locale,
+		Timezone:    a.Timezone,
+		Country:     a.Country,
+		City:        a.City,
+		Email:       a.Email,
+		Phone:       a.Phone,
+		// Only verification status is published, not its time
+		EmailVerified: a.EmailVerifiedAt != nil,
+		PhoneVerified: a.PhoneVerifiedAt != nil,
+		AvatarURLs:    avatarURLs(a.Avatar),
+	}
+
+	// Birthdate is omitted while unknown
+	if a.Birthdate != nil {
+		payload.Birthdate = a.Birthdate.Format(time.DateOnly)
+	}
+
+	return payload
+}
+
+// avatarURLs returns URLs of avatar thumbnails, nil if avatar is not set
+func avatarURLs(data []byte) map[string]string {
+	if avatar := decodeAvatar(data); avatar != nil {
+		return avatar.URLs
+	}
+	return nil
+}
+
+type OutboxRepository struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// Database inner structure
+type outboxEvent struct {
+	ID          int64           `db:"id"`
+	AggregateId string          `db:"aggregate_id"`
+	EventType   string          `db:"event_type"`
+	Payload     json.RawMessage `db:"payload"`
+	CreatedAt   time.Time       `db:"created_at"`
+	Attempts    int             `db:"attempts"`
+}
+
+// ProcessBatch locks up to limit pending events and passes them to publish.
+// Only the oldest pending event of every aggregate is taken, so events of one
+// user are never published out of order, even with several relays running.
+// Failed events are retried after backoff(attempts).
+func (r *OutboxRepository) ProcessBatch(ctx context.Context, limit int,
+	publish func(events.Message) error, backoff func(attempts int) time.Duration) (processed int, err error) {
+	query := `
+		SELECT o.id, o.aggregate_id, o.event_type, o.payload, o.created_at, o.attempts
+		FROM outbox o
+		WHERE o.published_at IS NULL
+			AND o.next_attempt_at <= CURRENT_TIMESTAMP
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox p
+				WHERE p.aggregate_id = o.aggregate_id
+					AND p.published_at IS NULL
+					AND p.id < o.id
+			)
+		ORDER BY o.id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	ctx, span := startSpan(ctx, "OutboxRepository.ProcessBatch", query)
+	defer func() { endSpan(span, err) }()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.New("failed to begin transaction: " + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	var pending []outboxEvent
+	if err = tx.SelectContext(ctx, &pending, query, limit); err != nil {
+		return 0, errors.New("failed to select events: " + err.Error())
+	}
+
+	for _, e := range pending {
+		publishErr := publish(e.message())
+
+		if publishErr == nil {
+			_, err = tx.ExecContext(ctx,
+				`UPDATE outbox SET published_at = CURRENT_TIMESTAMP, attempts = attempts + 1 WHERE id = $1`,
+				e.ID,
+			)
+		} else {
+			retryAt := time.Now().Add(backoff(e.Attempts + 1))
+			_, err = tx.ExecContext(ctx,
+				`UPDATE outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`,
+				e.ID, publishErr.Error(), retryAt,
+			)
+		}
+		if err != nil {
+			return processed, errors.New("failed to update event: " + err.Error())
+		}
+
+		processed++
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, errors.New("failed to commit transaction: " + err.Error())
+	}
+
+	return processed, nil
+}
+
+func (e outboxEvent) message() events.Message {
+	return events.Message{
+		ID:        e.ID,
+		Type:      e.EventType,
+		Key:       e.AggregateId,
+		Payload:   e.Payload,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+// Recent returns up to limit latest committed events in id order, published or not
+func (r *OutboxRepository) Recent(ctx context.Context, limit int) (_ []events.Message, err error) {
+	query := `
+		SELECT id, aggregate_id, event_type, payload, created_at FROM (
+			SELECT id, aggregate_id, event_type, payload, created_at
+			FROM outbox ORDER BY id DESC LIMIT $1
+		) recent
+		ORDER BY id
+	`
+
+	ctx, span := startSpan(ctx, "OutboxRepository.Recent", query)
+	defer func() { endSpan(span, err) }()
+
+	return r.selectMessages(ctx, query, limit)
+}
+
+// After returns up to limit committed events with id greater than afterId or in ids,
+// in id order. Ids are gaps left by transactions which had not committed yet.
+func (r *OutboxRepository) After(ctx context.Context, afterId int64, ids []int64,
+	limit int) (_ []events.Message, err error) {
+	query := `
+		SELECT id, aggregate_id, event_type, payload, created_at
+		FROM outbox
+		WHERE id > $1 OR id = ANY($2)
+		ORDER BY id
+		LIMIT $3
+	`
+
+	ctx, span := startSpan(ctx, "OutboxRepository.After", query)
+	defer func() { endSpan(span, err) }()
+
+	return r.selectMessages(ctx, query, afterId, pq.Array(ids), limit)
+}
+
+func (r *OutboxRepository) selectMessages(ctx context.Context, query string, args ...any) ([]events.Message, error) {
+	var rows []outboxEvent
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, errors.New("failed to select events: " + err.Error())
+	}
+
+	messages := make([]events.Message, 0, len(rows))
+	for _, e := range rows {
+		messages = append(messages, e.message())
+	}
+
+	return messages, nil
+}
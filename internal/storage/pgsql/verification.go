@@ -0,0 +1,232 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/events"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is PostgreSQL error code of unique constraint violation
+const uniqueViolation = "23505"
+
+type VerificationRepository struct {
+	db *sqlx.DB
+}
+
+func NewVerificationRepository(db *sqlx.DB) *VerificationRepository {
+	return &VerificationRepository{
+		db: db,
+	}
+}
+
+// Database inner structure
+type verificationCode struct {
+	UserId    uuid.UUID `db:"user_id"`
+	Channel   string    `db:"channel"`
+	Target    string    `db:"target"`
+	CodeHash  []byte    `db:"code_hash"`
+	Attempts  int       `db:"attempts"`
+	ExpiresAt time.Time `db:"expires_at"`
+	SentAt    time.Time `db:"sent_at"`
+}
+
+func (c verificationCode) domain() domain.Verification {
+	return domain.Verification{
+		UserId:    c.UserId,
+		Channel:   c.Channel,
+		Target:    c.Target,
+		CodeHash:  c.CodeHash,
+		Attempts:  c.Attempts,
+		ExpiresAt: c.ExpiresAt,
+		SentAt:    c.SentAt,
+	}
+}
+
+// Get returns pending verification of account contact
+func (r *VerificationRepository) Get(ctx context.Context, userId uuid.UUID, channel string) (_ *domain.Verification, err error) {
+	query := `
+		SELECT user_id, channel, target, code_hash, attempts, expires_at, sent_at
+		FROM verification_codes
+		WHERE user_id = $1 AND channel = $2
+	`
+
+	ctx, span := startSpan(ctx, "VerificationRepository.Get", query)
+	defer func() { endSpan(span, err) }()
+
+	var code verificationCode
+	err = r.db.GetContext(ctx, &code, query, userId, channel)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrVerificationNotFound
+	}
+	if err != nil {
+		return nil, errors.New("failed to get verification: " + err.Error())
+	}
+
+	result := code.domain()
+	return &result, nil
+}
+
+// Save replaces pending verification of account contact unless previous
+// code was sent after resendAfter, saved is false then
+func (r *VerificationRepository) Save(ctx context.Context, v domain.Verification, resendAfter time.Time) (saved bool, err error) {
+	query := `
+		INSERT INTO verification_codes (user_id, channel, target, code_hash, attempts, expires_at, sent_at)
+		VALUES (:user_id, :channel, :target, :code_hash, 0, :expires_at, :sent_at)
+		ON CONFLICT (user_id, channel) DO UPDATE SET
+			target = EXCLUDED.target,
+			code_hash = EXCLUDED.code_hash,
+			attempts = 0,
+			expires_at = EXCLUDED.expires_at,
+			sent_at = EXCLUDED.sent_at
+		WHERE verification_codes.sent_at <= :resend_after
+	`
+
+	ctx, span := startSpan(ctx, "VerificationRepository.Save", query)
+	defer func() { endSpan(span, err) }()
+
+	params := map[string]any{
+		"user_id":      v.UserId,
+		"channel":      v.Channel,
+		"target":       v.Target,
+		"code_hash":    v.CodeHash,
+		"expires_at":   v.ExpiresAt,
+		"sent_at":      v.SentAt,
+		"resend_after": resendAfter,
+	}
+
+	result, err := r.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return false, errors.New("failed to save verification: " + err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.New("failed to save verification: " + err.Error())
+	}
+
+	return affected > 0, nil
+}
+
+// Delete removes pending verification, e.g. when code could not be sent
+func (r *VerificationRepository) Delete(ctx context.Context, userId uuid.UUID, channel string) (err error) {
+	query := `DELETE FROM verification_codes WHERE user_id = $1 AND channel = $2`
+
+	ctx, span := startSpan(ctx, "VerificationRepository.Delete", query)
+	defer func() { endSpan(span, err) }()
+
+	if _, err = r.db.ExecContext(ctx, query, userId, channel); err != nil {
+		return errors.New("failed to delete verification: " + err.Error())
+	}
+
+	return nil
+}
+
+// Confirm locks pending verification and passes it to check. If check accepts
+// code, contact is marked verified and verification is removed. Failed attempt
+// is counted when check returns domain.ErrInvalidCode.
+func (r *VerificationRepository) Confirm(ctx context.Context, userId uuid.UUID, channel string,
+	check func(domain.Verification) error) (err error) {
+	if !domain.IsChannel(channel) {
+		return domain.ErrUnknownChannel
+	}
+
+	// Channel is column prefix, it is checked above
+	query := fmt.Sprintf(`
+		UPDATE accounts SET %[1]s_verified_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND %[1]s = $2
+		RETURNING %[2]s
+	`, channel, accountColumns)
+
+	ctx, span := startSpan(ctx, "VerificationRepository.Confirm", query)
+	defer func() { endSpan(span, err) }()
+
+	// Start transaction
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.New("failed to begin transaction: " + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Row lock serializes concurrent attempts, so attempts limit holds
+	var code verificationCode
+	err = tx.GetContext(ctx, &code, `
+		SELECT user_id, channel, target, code_hash, attempts, expires_at, sent_at
+		FROM verification_codes
+		WHERE user_id = $1 AND channel = $2
+		FOR UPDATE
+	`, userId, channel)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = domain.ErrVerificationNotFound
+		return err
+	}
+	if err != nil {
+		return errors.New("failed to get verification: " + err.Error())
+	}
+
+	if checkErr := check(code.domain()); checkErr != nil {
+		if !errors.Is(checkErr, domain.ErrInvalidCode) {
+			err = checkErr
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE verification_codes SET attempts = attempts + 1
+			WHERE user_id = $1 AND channel = $2
+		`, userId, channel)
+		if err != nil {
+			return errors.New("failed to count attempt: " + err.Error())
+		}
+		if err = tx.Commit(); err != nil {
+			return errors.New("failed to commit transaction: " + err.Error())
+		}
+
+		return checkErr
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM verification_codes WHERE user_id = $1 AND channel = $2`, userId, channel)
+	if err != nil {
+		return errors.New("failed to delete verification: " + err.Error())
+	}
+
+	var account Account
+	err = tx.GetContext(ctx, &account, query, userId, code.Target)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Contact was changed after code was sent, code is void
+		if err = tx.Commit(); err != nil {
+			return errors.New("failed to commit transaction: " + err.Error())
+		}
+		return domain.ErrVerificationNotFound
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		err = domain.ErrContactTaken
+		return err
+	}
+	if err != nil {
+		return errors.New("failed to verify contact: " + err.Error())
+	}
+
+	// Event carries state after verification
+	if err = insertEvent(ctx, tx, events.AccountUpdated, accountPayload(account)); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		return errors.New("failed to commit transaction: " + err.Error())
+	}
+
+	return nil
+}
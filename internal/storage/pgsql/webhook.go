@@ -0,0 +1,433 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/models/events"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type WebhookRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+// Database inner structures
+type webhookSubscription struct {
+	ID         uuid.UUID      `db:"id"`
+	URL        string         `db:"url"`
+	EventTypes pq.StringArray `db:"event_types"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (s webhookSubscription) dto() dtos.WebhookSubscription {
+	return dtos.WebhookSubscription{
+		ID:         s.ID,
+		URL:        s.URL,
+		EventTypes: []string(s.EventTypes),
+		CreatedAt:  s.CreatedAt,
+	}
+}
+
+type webhookDelivery struct {
+	ID             int64      `db:"id"`
+	SubscriptionId uuid.UUID  `db:"subscription_id"`
+	EventId        int64      `db:"event_id"`
+	EventType      string     `db:"event_type"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	LastStatusCode *int       `db:"last_status_code"`
+	LastError      *string    `db:"last_error"`
+	NextAttemptAt  *time.Time `db:"next_attempt_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+func (d webhookDelivery) dto() dtos.WebhookDelivery {
+	delivery := dtos.WebhookDelivery{
+		ID:             d.ID,
+		SubscriptionId: d.SubscriptionId,
+		EventId:        d.EventId,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+
+	// Next attempt is meaningful only while delivery is pending
+	if d.Status == dtos.WebhookDeliveryPending {
+		delivery.NextAttemptAt = d.NextAttemptAt
+	}
+
+	return delivery
+}
+
+type webhookAttempt struct {
+	Attempt    int       `db:"attempt"`
+	StatusCode *int      `db:"status_code"`
+	Error      *string   `db:"error"`
+	DurationMs int64     `db:"duration_ms"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// pendingDelivery is delivery claimed by dispatcher
+type pendingDelivery struct {
+	ID        int64           `db:"id"`
+	URL       string          `db:"url"`
+	Secret    string          `db:"secret"`
+	EventType string          `db:"event_type"`
+	Payload   json.RawMessage `db:"payload"`
+	Retries   int             `db:"retries"`
+}
+
+func (d pendingDelivery) domain() domain.PendingDelivery {
+	return domain.PendingDelivery{
+		ID:        d.ID,
+		URL:       d.URL,
+		Secret:    d.Secret,
+		EventType: d.EventType,
+		Payload:   d.Payload,
+		Retries:   d.Retries,
+	}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context,
+	req dtos.CreateWebhookRequest) (_ *dtos.WebhookSubscription, err error) {
+	query := `
+		INSERT INTO webhook_subscriptions (id, url, event_types, secret)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, url, event_types, created_at
+	`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.CreateSubscription", query)
+	defer func() { endSpan(span, err) }()
+
+	var subscription webhookSubscription
+	err = r.db.GetContext(ctx, &subscription, query,
+		uuid.New(), req.URL, pq.StringArray(req.EventTypes), req.Secret)
+	if err != nil {
+		return nil, errors.New("failed to insert webhook: " + err.Error())
+	}
+
+	response := subscription.dto()
+	return &response, nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) (_ []dtos.WebhookSubscription, err error) {
+	query := `SELECT id, url, event_types, created_at FROM webhook_subscriptions ORDER BY created_at, id`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.ListSubscriptions", query)
+	defer func() { endSpan(span, err) }()
+
+	var rows []webhookSubscription
+	if err = r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, errors.New("failed to get webhooks: " + err.Error())
+	}
+
+	subscriptions := make([]dtos.WebhookSubscription, 0, len(rows))
+	for _, row := range rows {
+		subscriptions = append(subscriptions, row.dto())
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes webhook together with its delivery log
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) (err error) {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.DeleteSubscription", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.New("failed to delete webhook: " + err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.New("failed to delete webhook: " + err.Error())
+	}
+	if affected == 0 {
+		err = domain.ErrWebhookNotFound
+		return err
+	}
+
+	return nil
+}
+
+// Enqueue creates deliveries of event for all subscriptions interested in it.
+// Repeated event is ignored, so relay redelivery does not duplicate webhooks.
+func (r *WebhookRepository) Enqueue(ctx context.Context, msg events.Message) (err error) {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT id, $1, $2, $3 FROM webhook_subscriptions WHERE $2 = ANY(event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.Enqueue", query)
+	defer func() { endSpan(span, err) }()
+
+	// Whole message is sent as webhook body
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return errors.New("failed to marshal event: " + err.Error())
+	}
+
+	_, err = r.db.ExecContext(ctx, query, msg.ID, msg.Type, body)
+	if err != nil {
+		return errors.New("failed to enqueue webhook deliveries: " + err.Error())
+	}
+
+	return nil
+}
+
+// ClaimDeliveries returns up to limit due pending deliveries and hides them
+// from other dispatchers for lease, so HTTP calls are made outside of transaction.
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int,
+	lease time.Duration) (_ []domain.PendingDelivery, err error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, s.url, s.secret, d.event_type, d.payload, d.attempts - d.retry_base AS retries
+	`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.ClaimDeliveries", query)
+	defer func() { endSpan(span, err) }()
+
+	var rows []pendingDelivery
+	err = r.db.SelectContext(ctx, &rows, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, errors.New("failed to claim webhook deliveries: " + err.Error())
+	}
+
+	deliveries := make([]domain.PendingDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, row.domain())
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt appends attempt to delivery log and updates delivery state
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, a domain.DeliveryAttempt) (err error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, status = $2, last_status_code = $3, last_error = $4,
+			next_attempt_at = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING attempts
+	`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.RecordAttempt", query)
+	defer func() { endSpan(span, err) }()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.New("failed to begin transaction: " + err.Error())
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	statusCode := nullInt(a.StatusCode)
+	attemptErr := nullString(a.Error)
+
+	// Finished deliveries have no next attempt
+	var nextAttemptAt *time.Time
+	if !a.NextAttemptAt.IsZero() {
+		nextAttemptAt = &a.NextAttemptAt
+	}
+
+	var attempt int
+	err = tx.GetContext(ctx, &attempt, query,
+		a.DeliveryId, a.Status, statusCode, attemptErr, nextAttemptAt)
+	if err != nil {
+		return errors.New("failed to update webhook delivery: " + err.Error())
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO webhook_attempts (delivery_id, attempt, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5)
+	`, a.DeliveryId, attempt, statusCode, attemptErr, a.Duration.Milliseconds())
+	if err != nil {
+		return errors.New("failed to insert webhook attempt: " + err.Error())
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.New("failed to commit transaction: " + err.Error())
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context,
+	f dtos.ListWebhookDeliveriesRequest) (_ []dtos.WebhookDelivery, err error) {
+	var conditions []string
+	params := map[string]any{
+		"limit":  f.Limit,
+		"offset": f.Offset,
+	}
+
+	if f.SubscriptionId != uuid.Nil {
+		conditions = append(conditions, "subscription_id = :subscription_id")
+		params["subscription_id"] = f.SubscriptionId
+	}
+	if f.Status != "" {
+		conditions = append(conditions, "status = :status")
+		params["status"] = f.Status
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, subscription_id, event_id, event_type, status, attempts,
+			last_status_code, last_error, next_attempt_at, created_at, updated_at
+		FROM webhook_deliveries %s
+		ORDER BY id DESC
+		LIMIT :limit OFFSET :offset
+	`, where)
+
+	ctx, span := startSpan(ctx, "WebhookRepository.ListDeliveries", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.New("failed to execute query: " + err.Error())
+	}
+	defer rows.Close()
+
+	deliveries := []dtos.WebhookDelivery{}
+	for rows.Next() {
+		var delivery webhookDelivery
+		if err = rows.StructScan(&delivery); err != nil {
+			return nil, errors.New("failed to get webhook delivery: " + err.Error())
+		}
+
+		deliveries = append(deliveries, delivery.dto())
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.New("failed to get webhook deliveries: " + err.Error())
+	}
+
+	return deliveries, nil
+}
+
+// GetDelivery returns delivery with its attempt log
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int64) (_ *dtos.WebhookDelivery, err error) {
+	query := `
+		SELECT id, subscription_id, event_id, event_type, status, attempts,
+			last_status_code, last_error, next_attempt_at, created_at, updated_at
+		FROM webhook_deliveries WHERE id = $1
+	`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.GetDelivery", query)
+	defer func() { endSpan(span, err) }()
+
+	var delivery webhookDelivery
+	err = r.db.GetContext(ctx, &delivery, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = domain.ErrDeliveryNotFound
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.New("failed to get webhook delivery: " + err.Error())
+	}
+
+	var attempts []webhookAttempt
+	err = r.db.SelectContext(ctx, &attempts, `
+		SELECT attempt, status_code, error, duration_ms, created_at
+		FROM webhook_attempts WHERE delivery_id = $1
+		ORDER BY id
+	`, id)
+	if err != nil {
+		return nil, errors.New("failed to get webhook attempts: " + err.Error())
+	}
+
+	response := delivery.dto()
+	for _, a := range attempts {
+		response.Log = append(response.Log, dtos.WebhookAttempt{
+			Attempt:    a.Attempt,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			DurationMs: a.DurationMs,
+			CreatedAt:  a.CreatedAt,
+		})
+	}
+
+	return &response, nil
+}
+
+// ReplayDelivery schedules delivery for immediate resend with fresh retry budget.
+// Attempt log is kept, new attempts continue its numbering.
+func (r *WebhookRepository) ReplayDelivery(ctx context.Context, id int64) (err error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'pending', retry_base = attempts, next_attempt_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	ctx, span := startSpan(ctx, "WebhookRepository.ReplayDelivery", query)
+	defer func() { endSpan(span, err) }()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.New("failed to replay webhook delivery: " + err.Error())
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.New("failed to replay webhook delivery: " + err.Error())
+	}
+	if affected == 0 {
+		err = domain.ErrDeliveryNotFound
+		return err
+	}
+
+	return nil
+}
+
+func nullInt(v int) *int {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+func nullString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/models/events"
+	"github.com/WebChads/AccountService/internal/storage/pgsql/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+// testDB connects to database from TEST_DATABASE_URL and migrates it,
+// tests are skipped if it is not set
+func testDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if err := migrations.RunMigrations(url, migrations.Options{}, logger); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+
+	db, err := sqlx.Connect("postgres", url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	db.MapperFunc(func(s string) string { return s })
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// claim returns claimed delivery with given id, other deliveries of shared database are ignored
+func claim(t *testing.T, r *WebhookRepository, id int64) (domain.PendingDelivery, bool) {
+	t.Helper()
+
+	deliveries, err := r.ClaimDeliveries(context.Background(), 1000, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimDeliveries() error = %v", err)
+	}
+
+	for _, d := range deliveries {
+		if d.ID == id {
+			return d, true
+		}
+	}
+
+	return domain.PendingDelivery{}, false
+}
+
+func TestWebhookReplayDeliveryResetsRetries(t *testing.T) {
+	ctx := context.Background()
+	r := NewWebhookRepository(testDB(t))
+
+	subscription, err := r.CreateSubscription(ctx, dtos.CreateWebhookRequest{
+		URL:        "http://127.0.0.1:1/hook",
+		EventTypes: []string{events.AccountCreated},
+		Secret:     "replay-test-secret",
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription() error = %v", err)
+	}
+	t.Cleanup(func() { r.DeleteSubscription(ctx, subscription.ID) })
+
+	err = r.Enqueue(ctx, events.Message{ID: time.Now().UnixNano(), Type: events.AccountCreated})
+	if err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	var id int64
+	err = r.db.GetContext(ctx, &id, `SELECT id FROM webhook_deliveries WHERE subscription_id = $1`, subscription.ID)
+	if err != nil {
+		t.Fatalf("select delivery: %v", err)
+	}
+
+	// Two failed attempts, second one exhausts budget
+	for attempt, status := range []string{dtos.WebhookDeliveryPending, dtos.WebhookDeliveryFailed} {
+		d, ok := claim(t, r, id)
+		if !ok {
+			t.Fatalf("delivery is not claimed for attempt %d", attempt+1)
+		}
+		if d.Retries != attempt {
+			t.Fatalf("retries = %d, want %d", d.Retries, attempt)
+		}
+
+		err = r.RecordAttempt(ctx, domain.DeliveryAttempt{
+			DeliveryId: id,
+			StatusCode: 502,
+			Error:      "unexpected status 502",
+			Status:     status,
+			// Past next attempt makes delivery due immediately
+			NextAttemptAt: time.Now().Add(-time.Second),
+		})
+		if err != nil {
+			t.Fatalf("RecordAttempt() error = %v", err)
+		}
+	}
+
+	if _, ok := claim(t, r, id); ok {
+		t.Fatal("failed delivery is claimed")
+	}
+
+	if err = r.ReplayDelivery(ctx, id); err != nil {
+		t.Fatalf("ReplayDelivery() error = %v", err)
+	}
+
+	d, ok := claim(t, r, id)
+	if !ok {
+		t.Fatal("replayed delivery is not claimed")
+	}
+	if d.Retries != 0 {
+		t.Fatalf("retries after replay = %d, want 0", d.Retries)
+	}
+
+	delivery, err := r.GetDelivery(ctx, id)
+	if err != nil {
+		t.Fatalf("GetDelivery() error = %v", err)
+	}
+	if delivery.Attempts != 2 || len(delivery.Log) != 2 {
+		t.Fatalf("attempts = %d, log = %d, want attempt log kept", delivery.Attempts, len(delivery.Log))
+	}
+}
@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+)
+
+// Broker fans out account events to live subscribers.
+// It is fed by Tail of outbox, so subscribers see exactly the events
+// committed together with account changes. Recent events are kept in
+// bounded log to let reconnecting clients resume.
+type Broker struct {
+	mu sync.Mutex
+
+	// log is ring buffer of recent events in arrival order
+	log   []events.Message
+	start int
+	size  int
+	// seen holds ids of logged events, the same event is never sent twice
+	seen map[int64]struct{}
+
+	subscribers map[*Subscription]struct{}
+	bufferSize  int
+}
+
+// Subscription receives events of one key, or all events when key is empty.
+// C is closed when subscriber falls behind and should reconnect.
+type Subscription struct {
+	C <-chan events.Message
+
+	ch  chan events.Message
+	key string
+}
+
+func NewBroker(logSize, bufferSize int) *Broker {
+	if logSize <= 0 {
+		logSize = 1000
+	}
+	if bufferSize <= 0 {
+		bufferSize = 64
+	}
+
+	return &Broker{
+		log:         make([]events.Message, logSize),
+		seen:        make(map[int64]struct{}, logSize),
+		subscribers: make(map[*Subscription]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Publish implements outbox publisher, it never fails
+func (b *Broker) Publish(_ context.Context, msg events.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.seen[msg.ID]; ok {
+		return nil
+	}
+
+	// Evict oldest event when log is full
+	if b.size == len(b.log) {
+		delete(b.seen, b.log[b.start].ID)
+		b.start = (b.start + 1) % len(b.log)
+		b.size--
+	}
+
+	b.log[(b.start+b.size)%len(b.log)] = msg
+	b.size++
+	b.seen[msg.ID] = struct{}{}
+
+	for s := range b.subscribers {
+		if s.key != "" && s.key != msg.Key {
+			continue
+		}
+
+		select {
+		case s.ch <- msg:
+		default:
+			// Slow subscriber would block everyone, it resumes from log after reconnect
+			close(s.ch)
+			delete(b.subscribers, s)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers subscriber for key and returns logged events to replay.
+// With lastEventId set, replay starts after that event; resumed is false when
+// it is no longer in log, then client must reload state as events were missed.
+func (b *Broker) Subscribe(key string, lastEventId *int64) (s *Subscription, replay []events.Message, resumed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan events.Message, b.bufferSize)
+	s = &Subscription{C: ch, ch: ch, key: key}
+	b.subscribers[s] = struct{}{}
+
+	if lastEventId == nil {
+		return s, nil, true
+	}
+
+	if _, ok := b.seen[*lastEventId]; !ok {
+		return s, nil, false
+	}
+
+	// Events are replayed in arrival order, ids may be out of order across keys
+	found := false
+	for i := 0; i < b.size; i++ {
+		msg := b.log[(b.start+i)%len(b.log)]
+		if found && (key == "" || key == msg.Key) {
+			replay = append(replay, msg)
+		}
+		if msg.ID == *lastEventId {
+			found = true
+		}
+	}
+
+	return s, replay, true
+}
+
+func (b *Broker) Unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[s]; ok {
+		close(s.ch)
+		delete(b.subscribers, s)
+	}
+}
@@ -0,0 +1,170 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+)
+
+// maxGaps limits tracked missing ids, larger jump of sequence is not waited for
+const maxGaps = 1000
+
+// TailRepository reads events committed to outbox, published or not
+type TailRepository interface {
+	Recent(ctx context.Context, limit int) ([]events.Message, error)
+	After(ctx context.Context, afterId int64, ids []int64, limit int) ([]events.Message, error)
+}
+
+type TailOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// LogSize is number of recent events loaded into broker on start
+	LogSize int
+	// GapTimeout is how long skipped ids are waited for. Ids are taken
+	// in insert order but committed in any, rolled back ones never appear.
+	GapTimeout time.Duration
+}
+
+// Tail feeds broker with events committed to outbox by any replica.
+// Relay of a single replica claims only part of events, so every replica
+// follows outbox itself and its subscribers see all of them.
+type Tail struct {
+	repository TailRepository
+	broker     *Broker
+	logger     *slog.Logger
+	opts       TailOptions
+
+	cursor int64
+	// gaps maps skipped ids to time they were noticed
+	gaps map[int64]time.Time
+}
+
+func NewTail(r TailRepository, b *Broker, l *slog.Logger, opts TailOptions) *Tail {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 500 * time.Millisecond
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.LogSize <= 0 {
+		opts.LogSize = 1000
+	}
+	if opts.GapTimeout <= 0 {
+		opts.GapTimeout = time.Minute
+	}
+
+	return &Tail{
+		repository: r,
+		broker:     b,
+		logger:     l,
+		opts:       opts,
+		gaps:       make(map[int64]time.Time),
+	}
+}
+
+// Run follows outbox until ctx is canceled
+func (t *Tail) Run(ctx context.Context) {
+	t.logger.Info("event stream tail started")
+	defer t.logger.Info("event stream tail stopped")
+
+	// Recent events let clients resume after reconnecting to another replica
+	for {
+		err := t.load(ctx)
+		if err == nil {
+			break
+		}
+		if ctx.Err() == nil {
+			t.logger.Error("failed to load recent events", slogerr.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(t.opts.PollInterval):
+		}
+	}
+
+	ticker := time.NewTicker(t.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Read while full batches are returned
+		for {
+			n, err := t.poll(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					t.logger.Error("failed to read events", slogerr.Error(err))
+				}
+				break
+			}
+			if n < t.opts.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Tail) load(ctx context.Context) error {
+	recent, err := t.repository.Recent(ctx, t.opts.LogSize)
+	if err != nil {
+		return err
+	}
+
+	if len(recent) > 0 {
+		// Gaps inside loaded window are waited for as well
+		t.cursor = recent[0].ID - 1
+	}
+	t.publish(ctx, recent, time.Now())
+
+	return nil
+}
+
+func (t *Tail) poll(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	gaps := make([]int64, 0, len(t.gaps))
+	for id, noticed := range t.gaps {
+		if now.Sub(noticed) > t.opts.GapTimeout {
+			delete(t.gaps, id)
+			continue
+		}
+		gaps = append(gaps, id)
+	}
+
+	messages, err := t.repository.After(ctx, t.cursor, gaps, t.opts.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	t.publish(ctx, messages, now)
+
+	return len(messages), nil
+}
+
+// publish passes messages in id order to broker and moves cursor past them
+func (t *Tail) publish(ctx context.Context, messages []events.Message, now time.Time) {
+	for _, msg := range messages {
+		if _, ok := t.gaps[msg.ID]; ok {
+			delete(t.gaps, msg.ID)
+		} else if msg.ID > t.cursor {
+			if skipped := msg.ID - t.cursor - 1; skipped > 0 && skipped <= maxGaps {
+				for id := t.cursor + 1; id < msg.ID; id++ {
+					t.gaps[id] = now
+				}
+			}
+			t.cursor = msg.ID
+		}
+
+		// Broker never fails and ignores repeated events
+		t.broker.Publish(ctx, msg)
+	}
+}
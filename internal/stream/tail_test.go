@@ -0,0 +1,151 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+)
+
+// memoryOutbox holds committed events, like outbox table does
+type memoryOutbox struct {
+	committed map[int64]events.Message
+}
+
+func (o *memoryOutbox) commit(ids ...int64) {
+	for _, id := range ids {
+		o.committed[id] = events.Message{ID: id, Type: events.AccountUpdated, Key: "user"}
+	}
+}
+
+func (o *memoryOutbox) sorted(match func(id int64) bool) []events.Message {
+	var messages []events.Message
+	for id, msg := range o.committed {
+		if match(id) {
+			messages = append(messages, msg)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+
+	return messages
+}
+
+func (o *memoryOutbox) Recent(_ context.Context, limit int) ([]events.Message, error) {
+	messages := o.sorted(func(int64) bool { return true })
+	if len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+
+	return messages, nil
+}
+
+func (o *memoryOutbox) After(_ context.Context, afterId int64, ids []int64, limit int) ([]events.Message, error) {
+	messages := o.sorted(func(id int64) bool { return id > afterId || slices.Contains(ids, id) })
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+
+	return messages, nil
+}
+
+func newTestTail(o *memoryOutbox, b *Broker, opts TailOptions) *Tail {
+	return NewTail(o, b, slog.New(slog.NewTextHandler(io.Discard, nil)), opts)
+}
+
+// received drains subscription and returns ids in arrival order
+func received(s *Subscription) []int64 {
+	var ids []int64
+	for {
+		select {
+		case msg := <-s.C:
+			ids = append(ids, msg.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func poll(t *testing.T, tail *Tail) {
+	t.Helper()
+
+	if _, err := tail.poll(context.Background()); err != nil {
+		t.Fatalf("poll() error = %v", err)
+	}
+}
+
+func TestTailLoadsRecentEvents(t *testing.T) {
+	outbox := &memoryOutbox{committed: map[int64]events.Message{}}
+	outbox.commit(1, 2, 3, 4)
+
+	broker := NewBroker(10, 10)
+	tail := newTestTail(outbox, broker, TailOptions{LogSize: 2})
+	if err := tail.load(context.Background()); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	// Client of another replica resumes after event 3
+	lastEventId := int64(3)
+	_, replay, resumed := broker.Subscribe("", &lastEventId)
+	if !resumed || len(replay) != 1 || replay[0].ID != 4 {
+		t.Fatalf("Subscribe() replay = %v, resumed = %t, want event 4", replay, resumed)
+	}
+}
+
+func TestTailDeliversEventsCommittedOutOfOrder(t *testing.T) {
+	outbox := &memoryOutbox{committed: map[int64]events.Message{}}
+	broker := NewBroker(10, 10)
+	s, _, _ := broker.Subscribe("", nil)
+
+	tail := newTestTail(outbox, broker, TailOptions{GapTimeout: time.Minute})
+
+	// Transaction of event 2 commits after event 3
+	outbox.commit(1, 3)
+	poll(t, tail)
+	outbox.commit(2, 4)
+	poll(t, tail)
+	// Nothing is delivered twice
+	poll(t, tail)
+
+	if got, want := received(s), []int64{1, 3, 2, 4}; !slices.Equal(got, want) {
+		t.Fatalf("received %v, want %v", got, want)
+	}
+	if len(tail.gaps) != 0 {
+		t.Fatalf("gaps = %v, want none", tail.gaps)
+	}
+}
+
+func TestTailForgetsRolledBackIds(t *testing.T) {
+	outbox := &memoryOutbox{committed: map[int64]events.Message{}}
+	tail := newTestTail(outbox, NewBroker(10, 10), TailOptions{GapTimeout: time.Minute})
+
+	outbox.commit(1, 3)
+	poll(t, tail)
+	if _, ok := tail.gaps[2]; !ok {
+		t.Fatalf("gaps = %v, want id 2 waited for", tail.gaps)
+	}
+
+	// Transaction of event 2 was rolled back
+	tail.gaps[2] = time.Now().Add(-2 * time.Minute)
+	poll(t, tail)
+
+	if len(tail.gaps) != 0 {
+		t.Fatalf("gaps = %v, want expired gap removed", tail.gaps)
+	}
+}
+
+func TestTailIgnoresLargeSequenceJump(t *testing.T) {
+	outbox := &memoryOutbox{committed: map[int64]events.Message{}}
+	tail := newTestTail(outbox, NewBroker(10, 10), TailOptions{})
+
+	outbox.commit(1, maxGaps+10)
+	poll(t, tail)
+
+	if len(tail.gaps) != 0 || tail.cursor != maxGaps+10 {
+		t.Fatalf("gaps = %d, cursor = %d, want no gaps after jump", len(tail.gaps), tail.cursor)
+	}
+}
@@ -3,57 +3,286 @@ package usecase
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"time"
 
-	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/blob"
+	"github.com/WebChads/AccountService/internal/models/domain"
 	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
+	"github.com/go-playground/validator"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 )
 
+var tracer = otel.Tracer("github.com/WebChads/AccountService/internal/usecase")
+
+const defaultSearchLimit = 50
+
 type AccountUsecase struct {
 	logger     *slog.Logger
 	repository AccountRepository
+	// validate checks rows of bulk import, other requests are validated by delivery
+	validate *validator.Validate
+	genders  domain.Genders
+	// avatars keeps account pictures, they are removed with account
+	avatars blob.BlobStore
 }
 
-func NewAccountUsecase(r AccountRepository, l *slog.Logger) *AccountUsecase {
+func NewAccountUsecase(r AccountRepository, avatars blob.BlobStore, genders domain.Genders, l *slog.Logger) *AccountUsecase {
 	return &AccountUsecase{
 		logger:     l,
 		repository: r,
+		validate:   validation.NewAccountValidator(genders),
+		genders:    genders,
+		avatars:    avatars,
+	}
+}
+
+// Genders returns genders allowed in accounts
+func (a *AccountUsecase) Genders() domain.Genders {
+	return a.genders
+}
+
+// checkGender accepts codes of allowed genders, empty gender is not checked
+func (a *AccountUsecase) checkGender(gender string) error {
+	if gender != "" && !slices.Contains(a.genders.Codes(), gender) {
+		return domain.ErrInvalidGender
 	}
+
+	return nil
+}
+
+// log returns request-scoped logger if there is one
+func (a *AccountUsecase) log(ctx context.Context) *slog.Logger {
+	return slogerr.FromContext(ctx, a.logger)
 }
 
-func (a *AccountUsecase) Get(ctx context.Context, userId string) (*dtos.GetAccountResponse, error) {
+func (a *AccountUsecase) Get(ctx context.Context, userId string) (*domain.Account, error) {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userId))
+
 	id, err := uuid.Parse(userId)
 	if err != nil {
-		a.logger.Error("user id parsing error", slogerr.Error(err))
+		a.log(ctx).Error("user id parsing error", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
 	account, err := a.repository.Select(ctx, id)
 	if err != nil {
-		a.logger.Error("get account", slogerr.Error(err))
+		a.log(ctx).Error("get account", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
-	// Calculate user age (hardcode for now)
-	account.Age = (time.Now().Year() - account.Birthdate.Year())
-	if (time.Now().Month() < account.Birthdate.Month()) {
-		account.Age--;
-	} else if (time.Now().Month() == account.Birthdate.Month()) {
-		if (time.Now().Day() < account.Birthdate.Day()) {
-			account.Age--;
+	return account, nil
+}
+
+// Create stores new account, birthdate must be known
+func (a *AccountUsecase) Create(ctx context.Context, account domain.Account) error {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", account.UserId.String()))
+
+	if !account.HasBirthdate() {
+		span.SetStatus(codes.Error, domain.ErrNoBirthdate.Error())
+		return domain.ErrNoBirthdate
+	}
+
+	if err := a.insert(ctx, account); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// Provision creates account of user registered in other service.
+// Unlike Create, birthdate may be unknown until user sets it.
+func (a *AccountUsecase) Provision(ctx context.Context, account domain.Account) error {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Provision")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", account.UserId.String()))
+
+	if err := a.insert(ctx, account); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// insert checks account invariants and stores it
+func (a *AccountUsecase) insert(ctx context.Context, account domain.Account) error {
+	if err := account.Validate(); err != nil {
+		return err
+	}
+	if err := a.checkGender(account.Gender); err != nil {
+		return err
+	}
+
+	err := a.repository.Insert(ctx, account)
+	if err != nil {
+		a.log(ctx).Error("create account", slogerr.Error(err))
+		return err
+	}
+
+	a.audit(ctx, "account.create", account.UserId)
+
+	return nil
+}
+
+func (a *AccountUsecase) Update(ctx context.Context, changes domain.AccountChanges) error {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", changes.UserId.String()))
+
+	changes = changes.Normalized()
+	if err := changes.Validate(); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if changes.Gender != nil {
+		if err := a.checkGender(*changes.Gender); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return err
 		}
 	}
 
-	return account, nil
+	err := a.repository.Update(ctx, changes)
+	if err != nil {
+		a.log(ctx).Error("update account", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	a.audit(ctx, "account.update", changes.UserId)
+
+	return nil
 }
 
-func (a *AccountUsecase) Create(ctx context.Context, req dtos.CreateAccountRequest) error {
-	err := a.repository.Insert(ctx, req)
+func (a *AccountUsecase) Delete(ctx context.Context, userId string) error {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userId))
+
+	id, err := uuid.Parse(userId)
 	if err != nil {
-		a.logger.Error("create account", slogerr.Error(err))
+		a.log(ctx).Error("user id parsing error", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 
+	avatarKeys, err := a.repository.Delete(ctx, id)
+	if err != nil {
+		a.log(ctx).Error("delete account", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	a.audit(ctx, "account.delete", id)
+
+	// Files are removed after commit, account is deleted even if it fails
+	removeFiles(ctx, a.avatars, a.log(ctx), avatarKeys)
+
 	return nil
 }
+
+func (a *AccountUsecase) Search(ctx context.Context, filter domain.AccountFilter) ([]domain.Account, error) {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Search")
+	defer span.End()
+
+	if err := a.checkGender(filter.Gender); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultSearchLimit
+	}
+
+	accounts, err := a.repository.Search(ctx, filter)
+	if err != nil {
+		a.log(ctx).Error("search accounts", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// FuzzySearch finds accounts by full name tolerating typos, best matches first
+func (a *AccountUsecase) FuzzySearch(ctx context.Context, query string, limit, offset int) ([]domain.AccountMatch, error) {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.FuzzySearch")
+	defer span.End()
+
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	matches, err := a.repository.FuzzySearch(ctx, query, limit, offset)
+	if err != nil {
+		a.log(ctx).Error("fuzzy search accounts", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("search.matches", len(matches)))
+	a.log(ctx).Info("audit",
+		"action", "account.search",
+		"matches", len(matches),
+		"actor", actorFromContext(ctx),
+	)
+
+	return matches, nil
+}
+
+// Birthdays returns accounts with birthdays on days from..to in order of celebration
+func (a *AccountUsecase) Birthdays(ctx context.Context, from, to time.Time, limit, offset int) ([]domain.Birthday, error) {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Birthdays")
+	defer span.End()
+
+	keys, err := domain.BirthdayKeys(from, to)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	span.SetAttributes(
+		attribute.String("birthdays.from", from.Format(time.DateOnly)),
+		attribute.String("birthdays.to", to.Format(time.DateOnly)),
+	)
+
+	accounts, err := a.repository.Birthdays(ctx, keys, limit, offset)
+	if err != nil {
+		a.log(ctx).Error("birthdays", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	birthdays := make([]domain.Birthday, 0, len(accounts))
+	for _, account := range accounts {
+		date := account.NextBirthday(from)
+		birthdays = append(birthdays, domain.Birthday{
+			Account: account,
+			Date:    date,
+			Age:     account.AgeOn(date),
+		})
+	}
+
+	return birthdays, nil
+}
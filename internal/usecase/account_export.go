@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/pkg/bulk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Export streams accounts matching filter to writer and returns number of exported rows.
+// Names and birth day and month are masked with maskPII. Writer is not closed.
+func (a *AccountUsecase) Export(ctx context.Context, filter domain.AccountFilter, maskPII bool, writer bulk.Writer) (int, error) {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Export")
+	defer span.End()
+
+	if err := a.checkGender(filter.Gender); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	exported := 0
+	err := a.repository.Export(ctx, filter, func(account domain.Account) error {
+		record := dtos.NewExportAccountRecord(account)
+		if maskPII {
+			maskRecord(&record)
+		}
+
+		exported++
+		return writer.Write(record)
+	})
+	if err != nil {
+		a.log(ctx).Error("export accounts", "exported", exported, "error", err.Error())
+		span.SetStatus(codes.Error, err.Error())
+		return exported, err
+	}
+
+	span.SetAttributes(attribute.Int("export.exported", exported))
+	a.log(ctx).Info("audit",
+		"action", "account.export",
+		"exported", exported,
+		"mask_pii", maskPII,
+		"actor", actorFromContext(ctx),
+	)
+
+	return exported, nil
+}
+
+// maskRecord keeps first letters of names and birth year only
+func maskRecord(record *dtos.ExportAccountRecord) {
+	record.Firstname = maskName(record.Firstname)
+	record.Surname = maskName(record.Surname)
+	record.Patronymic = maskName(record.Patronymic)
+
+	if len(record.Birthdate) >= 4 {
+		record.Birthdate = record.Birthdate[:4]
+	}
+}
+
+func maskName(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	first, _ := utf8.DecodeRuneInString(name)
+	return string(first) + "***"
+}
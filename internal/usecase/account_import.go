@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/pkg/bulk"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const defaultImportBatchSize = 1000
+
+type ImportOptions struct {
+	BatchSize int
+	// SkipLines is the last line processed by previous run
+	SkipLines int
+	// OnReject is called for every rejected row after its batch is committed
+	OnReject func(dtos.ImportReject) error
+	// OnBatch is called after every committed batch, e.g. to save checkpoint
+	OnBatch func(dtos.ImportResult) error
+	// Language of validation messages in rejects, English by default
+	Language string
+}
+
+type importBatch struct {
+	accounts []domain.Account
+	lines    map[uuid.UUID]importLine
+	rejects  []dtos.ImportReject
+	rows     int
+	lastLine int
+}
+
+type importLine struct {
+	line int
+	raw  string
+}
+
+// Import streams rows through the same validation as Create and inserts them in batches
+func (a *AccountUsecase) Import(ctx context.Context, reader bulk.Reader, opts ImportOptions) (dtos.ImportResult, error) {
+	ctx, span := tracer.Start(ctx, "AccountUsecase.Import")
+	defer span.End()
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultImportBatchSize
+	}
+
+	result := dtos.ImportResult{LastLine: opts.SkipLines}
+	batch := newImportBatch(opts.BatchSize)
+
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return result, fmt.Errorf("failed to read import row: %w", err)
+		}
+
+		// Already imported by previous run
+		if row.Line <= opts.SkipLines {
+			continue
+		}
+
+		batch.rows++
+		batch.lastLine = row.Line
+		a.addImportRow(batch, row, opts.Language)
+
+		if batch.rows >= opts.BatchSize {
+			if err := a.flushImportBatch(ctx, batch, &result, opts); err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				return result, err
+			}
+			batch = newImportBatch(opts.BatchSize)
+		}
+	}
+
+	if batch.rows > 0 {
+		if err := a.flushImportBatch(ctx, batch, &result, opts); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return result, err
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("import.imported", result.Imported),
+		attribute.Int("import.rejected", result.Rejected),
+	)
+
+	return result, nil
+}
+
+func newImportBatch(size int) *importBatch {
+	return &importBatch{
+		accounts: make([]domain.Account, 0, size),
+		lines:    make(map[uuid.UUID]importLine, size),
+	}
+}
+
+func (a *AccountUsecase) addImportRow(batch *importBatch, row bulk.Row, lang string) {
+	reject := func(err error) {
+		batch.rejects = append(batch.rejects, dtos.ImportReject{
+			Line:   row.Line,
+			Error:  err.Error(),
+			Record: row.Raw,
+		})
+	}
+
+	if row.Err != nil {
+		reject(row.Err)
+		return
+	}
+
+	userId, err := uuid.Parse(row.Record.UserId)
+	if err != nil {
+		reject(fmt.Errorf("invalid user_id: %w", err))
+		return
+	}
+
+	birthdate, err := dtos.ParseDate(row.Record.Birthdate)
+	if err != nil {
+		reject(fmt.Errorf("invalid birthdate: %w", err))
+		return
+	}
+
+	req := dtos.CreateAccountRequest{
+		UserId:     userId,
+		Firstname:  row.Record.Firstname,
+		Surname:    row.Record.Surname,
+		Patronymic: row.Record.Patronymic,
+		Gender:     row.Record.Gender,
+		Birthdate:  birthdate,
+	}
+
+	if err := a.validate.Struct(req); err != nil {
+		reject(validationError(err, lang))
+		return
+	}
+
+	account, err := req.ToDomain(a.genders)
+	if err != nil {
+		reject(err)
+		return
+	}
+
+	if _, ok := batch.lines[userId]; ok {
+		reject(errors.New("duplicate user_id in import"))
+		return
+	}
+
+	batch.accounts = append(batch.accounts, account)
+	batch.lines[userId] = importLine{line: row.Line, raw: row.Raw}
+}
+
+func (a *AccountUsecase) flushImportBatch(ctx context.Context, batch *importBatch,
+	result *dtos.ImportResult, opts ImportOptions) error {
+	if len(batch.accounts) > 0 {
+		inserted, err := a.repository.CopyInsert(ctx, batch.accounts)
+		if err != nil {
+			a.log(ctx).Error("import accounts", "line", batch.lastLine, "error", err.Error())
+			return err
+		}
+
+		// Accounts not inserted already existed
+		for _, id := range inserted {
+			delete(batch.lines, id)
+		}
+		for _, l := range batch.lines {
+			batch.rejects = append(batch.rejects, dtos.ImportReject{
+				Line:   l.line,
+				Error:  domain.ErrAccountExists.Error(),
+				Record: l.raw,
+			})
+		}
+
+		result.Imported += len(inserted)
+		a.log(ctx).Info("audit",
+			"action", "account.import",
+			"imported", len(inserted),
+			"last_line", batch.lastLine,
+			"actor", actorFromContext(ctx),
+		)
+	}
+
+	sort.Slice(batch.rejects, func(i, j int) bool {
+		return batch.rejects[i].Line < batch.rejects[j].Line
+	})
+
+	result.Processed += batch.rows
+	result.Rejected += len(batch.rejects)
+	result.LastLine = batch.lastLine
+
+	if opts.OnReject != nil {
+		for _, reject := range batch.rejects {
+			if err := opts.OnReject(reject); err != nil {
+				return fmt.Errorf("failed to write reject: %w", err)
+			}
+		}
+	}
+
+	if opts.OnBatch != nil {
+		if err := opts.OnBatch(*result); err != nil {
+			return fmt.Errorf("failed to save import progress: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validationError joins field errors into single message in language
+func validationError(err error, lang string) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	trans := validation.Translator(lang)
+	messages := make([]string, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		messages = append(messages, validation.Message(fieldErr, trans))
+	}
+
+	return errors.New(strings.Join(messages, "; "))
+}
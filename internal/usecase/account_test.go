@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/blob"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/google/uuid"
+)
+
+// insertRepository keeps inserted accounts, other methods are not used
+type insertRepository struct {
+	AccountRepository
+	inserted []domain.Account
+}
+
+func (r *insertRepository) Insert(_ context.Context, account domain.Account) error {
+	r.inserted = append(r.inserted, account)
+	return nil
+}
+
+// deleteRepository deletes account with avatar of given keys
+type deleteRepository struct {
+	AccountRepository
+	avatarKeys []string
+}
+
+func (r *deleteRepository) Delete(context.Context, uuid.UUID) ([]string, error) {
+	return r.avatarKeys, nil
+}
+
+func newTestAccountUsecase(r AccountRepository) *AccountUsecase {
+	return NewAccountUsecase(r, nil, builtinGenders(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func builtinGenders() domain.Genders {
+	genders, err := domain.NewGenderCatalog()
+	if err != nil {
+		panic(err)
+	}
+
+	return genders
+}
+
+func TestAccountUsecaseProvisionWithoutBirthdate(t *testing.T) {
+	repo := &insertRepository{}
+	account := domain.Account{
+		UserId:    uuid.New(),
+		Firstname: "Иван",
+		Surname:   "-",
+		Gender:    domain.GenderUndisclosed,
+	}
+
+	// Clients must always send birthdate
+	if err := newTestAccountUsecase(repo).Create(context.Background(), account); !errors.Is(err, domain.ErrNoBirthdate) {
+		t.Fatalf("Create() error = %v, want %v", err, domain.ErrNoBirthdate)
+	}
+
+	if err := newTestAccountUsecase(repo).Provision(context.Background(), account); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if len(repo.inserted) != 1 || repo.inserted[0].HasBirthdate() {
+		t.Fatalf("inserted = %+v, want one account with unknown birthdate", repo.inserted)
+	}
+}
+
+func TestAccountUsecaseProvisionValidatesKnownBirthdate(t *testing.T) {
+	account := domain.Account{
+		UserId:    uuid.New(),
+		Firstname: "Иван",
+		Surname:   "Иванов",
+		Gender:    domain.GenderUndisclosed,
+		Birthdate: time.Date(1800, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	err := newTestAccountUsecase(&insertRepository{}).Provision(context.Background(), account)
+	if !errors.Is(err, domain.ErrInvalidBirthdate) {
+		t.Fatalf("Provision() error = %v, want %v", err, domain.ErrInvalidBirthdate)
+	}
+}
+
+func TestAccountUsecaseRejectsGenderOutsideCatalog(t *testing.T) {
+	genders, err := domain.AllowedGenders(builtinGenders(), []string{domain.GenderUndisclosed})
+	if err != nil {
+		t.Fatalf("AllowedGenders() error = %v", err)
+	}
+
+	repo := &insertRepository{}
+	u := NewAccountUsecase(repo, nil, genders, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	account := domain.Account{
+		UserId:    uuid.New(),
+		Firstname: "Иван",
+		Surname:   "Иванов",
+		Gender:    domain.GenderMale,
+	}
+	if err := u.Provision(context.Background(), account); !errors.Is(err, domain.ErrInvalidGender) {
+		t.Fatalf("Provision() error = %v, want %v", err, domain.ErrInvalidGender)
+	}
+	if len(repo.inserted) != 0 {
+		t.Fatalf("inserted = %+v, want none", repo.inserted)
+	}
+}
+
+func TestAccountUsecaseDeleteRemovesAvatarFiles(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := blob.NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFSStore() error = %v", err)
+	}
+
+	keys := []string{"avatars/user/1/64.png", "avatars/user/1/128.png"}
+	for _, key := range keys {
+		if err = store.Put(ctx, key, strings.NewReader("png"), 3, "image/png"); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+
+	repo := &deleteRepository{avatarKeys: keys}
+	u := NewAccountUsecase(repo, store, builtinGenders(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err = u.Delete(ctx, uuid.NewString()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	for _, key := range keys {
+		if _, _, err = store.Get(ctx, key); !errors.Is(err, blob.ErrNotFound) {
+			t.Errorf("Get(%q) error = %v, want file removed", key, err)
+		}
+	}
+}
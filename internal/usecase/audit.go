@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type actorKey struct{}
+
+// WithActor sets who performs account changes, e.g. "cli:admin".
+// Authenticated user id is used when actor is not set.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+
+	// Set by auth middleware
+	if userId, ok := ctx.Value("user_id").(string); ok && userId != "" {
+		return "user:" + userId
+	}
+
+	return "unknown"
+}
+
+// audit writes account change record to log
+func (a *AccountUsecase) audit(ctx context.Context, action string, accountId uuid.UUID) {
+	a.log(ctx).Info("audit",
+		"action", action,
+		"account_id", accountId.String(),
+		"actor", actorFromContext(ctx),
+	)
+}
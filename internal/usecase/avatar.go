@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/blob"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/pkg/imaging"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type AvatarOptions struct {
+	// MaxSize limits uploaded file in bytes
+	MaxSize int64
+	// MaxPixels limits decoded image width * height
+	MaxPixels int
+	// Sizes are sides of square thumbnails in pixels
+	Sizes []int
+	// BaseURL prefixes blob keys in avatar URLs
+	BaseURL string
+}
+
+// AvatarUsecase stores account pictures as thumbnails without metadata
+type AvatarUsecase struct {
+	logger     *slog.Logger
+	repository AccountRepository
+	store      blob.BlobStore
+	opts       AvatarOptions
+}
+
+func NewAvatarUsecase(r AccountRepository, store blob.BlobStore, l *slog.Logger, opts AvatarOptions) *AvatarUsecase {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = 5 << 20
+	}
+	if opts.MaxPixels <= 0 {
+		opts.MaxPixels = 40_000_000
+	}
+	if len(opts.Sizes) == 0 {
+		opts.Sizes = []int{64, 128, 256, 512}
+	}
+
+	// Smaller thumbnails are scaled from the largest one
+	opts.Sizes = slices.Clone(opts.Sizes)
+	slices.Sort(opts.Sizes)
+	opts.Sizes = slices.Compact(opts.Sizes)
+	slices.Reverse(opts.Sizes)
+
+	opts.BaseURL = strings.TrimRight(opts.BaseURL, "/")
+
+	return &AvatarUsecase{
+		logger:     l,
+		repository: r,
+		store:      store,
+		opts:       opts,
+	}
+}
+
+func (u *AvatarUsecase) log(ctx context.Context) *slog.Logger {
+	return slogerr.FromContext(ctx, u.logger)
+}
+
+// MaxSize returns limit of uploaded file in bytes
+func (u *AvatarUsecase) MaxSize() int64 {
+	return u.opts.MaxSize
+}
+
+// Upload replaces account avatar with image read from r.
+// Image type is sniffed from content, EXIF orientation is applied
+// and all metadata is dropped by re-encoding.
+func (u *AvatarUsecase) Upload(ctx context.Context, userId uuid.UUID, r io.Reader) (*domain.Avatar, error) {
+	ctx, span := tracer.Start(ctx, "AvatarUsecase.Upload")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userId.String()))
+
+	data, err := io.ReadAll(io.LimitReader(r, u.opts.MaxSize+1))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if int64(len(data)) > u.opts.MaxSize {
+		span.SetStatus(codes.Error, domain.ErrAvatarTooLarge.Error())
+		return nil, domain.ErrAvatarTooLarge
+	}
+
+	span.SetAttributes(attribute.Int("avatar.size", len(data)))
+
+	img, orientation, err := imaging.Decode(data, u.opts.MaxPixels)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	avatar := &domain.Avatar{
+		ID:         uuid.New(),
+		URLs:       make(map[string]string, len(u.opts.Sizes)),
+		UploadedAt: time.Now(),
+	}
+
+	// Orientation is applied to the largest thumbnail, not to full image
+	largest := imaging.Orient(imaging.Square(img, u.opts.Sizes[0]), orientation)
+	for _, size := range u.opts.Sizes {
+		thumbnail := largest
+		if size != u.opts.Sizes[0] {
+			thumbnail = imaging.Square(largest, size)
+		}
+
+		encoded, contentType, err := imaging.Encode(thumbnail)
+		if err != nil {
+			removeFiles(ctx, u.store, u.log(ctx), avatar.Keys)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		key := domain.AvatarKey(userId, avatar.ID, size, imaging.Extension(contentType))
+		err = u.store.Put(ctx, key, bytes.NewReader(encoded), int64(len(encoded)), contentType)
+		if err != nil {
+			u.log(ctx).Error("put avatar", slogerr.Error(err))
+			removeFiles(ctx, u.store, u.log(ctx), avatar.Keys)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		avatar.Keys = append(avatar.Keys, key)
+		avatar.URLs[strconv.Itoa(size)] = u.opts.BaseURL + "/" + key
+	}
+
+	previous, err := u.repository.SetAvatar(ctx, userId, avatar)
+	if err != nil {
+		u.log(ctx).Error("set avatar", slogerr.Error(err))
+		removeFiles(ctx, u.store, u.log(ctx), avatar.Keys)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if previous != nil {
+		removeFiles(ctx, u.store, u.log(ctx), previous.Keys)
+	}
+
+	u.log(ctx).Info("audit",
+		"action", "avatar.upload",
+		"account_id", userId.String(),
+		"avatar_id", avatar.ID.String(),
+		"actor", actorFromContext(ctx),
+	)
+
+	return avatar, nil
+}
+
+// Delete removes account avatar and its files
+func (u *AvatarUsecase) Delete(ctx context.Context, userId uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "AvatarUsecase.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userId.String()))
+
+	account, err := u.repository.Select(ctx, userId)
+	if err != nil {
+		u.log(ctx).Error("get account", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	if account.Avatar == nil {
+		span.SetStatus(codes.Error, domain.ErrAvatarNotSet.Error())
+		return domain.ErrAvatarNotSet
+	}
+
+	previous, err := u.repository.SetAvatar(ctx, userId, nil)
+	if err != nil {
+		u.log(ctx).Error("delete avatar", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if previous != nil {
+		removeFiles(ctx, u.store, u.log(ctx), previous.Keys)
+	}
+
+	u.log(ctx).Info("audit",
+		"action", "avatar.delete",
+		"account_id", userId.String(),
+		"actor", actorFromContext(ctx),
+	)
+
+	return nil
+}
+
+// Open returns avatar file by blob key, caller must close it
+func (u *AvatarUsecase) Open(ctx context.Context, key string) (io.ReadCloser, blob.Info, error) {
+	ctx, span := tracer.Start(ctx, "AvatarUsecase.Open")
+	defer span.End()
+
+	file, info, err := u.store.Get(ctx, key)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, blob.Info{}, err
+	}
+
+	return file, info, nil
+}
+
+// removeFiles deletes blobs best effort, files left behind are only wasted space
+func removeFiles(ctx context.Context, store blob.BlobStore, logger *slog.Logger, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			logger.Warn("delete avatar file", "key", key, slogerr.Error(err))
+		}
+	}
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"time"
 
+	"github.com/WebChads/AccountService/internal/models/domain"
 	"github.com/WebChads/AccountService/internal/models/dtos"
 	storage "github.com/WebChads/AccountService/internal/storage/pgsql"
 	"github.com/google/uuid"
@@ -10,19 +12,48 @@ import (
 )
 
 type AccountRepository interface {
-	Select(ctx context.Context, userId uuid.UUID) (*dtos.GetAccountResponse, error)
-	Insert(ctx context.Context, account dtos.CreateAccountRequest) error
+	Select(ctx context.Context, userId uuid.UUID) (*domain.Account, error)
+	Insert(ctx context.Context, account domain.Account) error
+	Update(ctx context.Context, changes domain.AccountChanges) error
+	// Delete returns blob keys of deleted avatar
+	Delete(ctx context.Context, userId uuid.UUID) ([]string, error)
+	Search(ctx context.Context, filter domain.AccountFilter) ([]domain.Account, error)
+	Birthdays(ctx context.Context, keys []int, limit, offset int) ([]domain.Account, error)
+	FuzzySearch(ctx context.Context, name string, limit, offset int) ([]domain.AccountMatch, error)
+	CopyInsert(ctx context.Context, accounts []domain.Account) ([]uuid.UUID, error)
+	Export(ctx context.Context, filter domain.AccountFilter, fn func(domain.Account) error) error
+	SetAvatar(ctx context.Context, userId uuid.UUID, avatar *domain.Avatar) (*domain.Avatar, error)
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, req dtos.CreateWebhookRequest) (*dtos.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]dtos.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	ListDeliveries(ctx context.Context, filter dtos.ListWebhookDeliveriesRequest) ([]dtos.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, id int64) (*dtos.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, id int64) error
+}
+
+type VerificationRepository interface {
+	Get(ctx context.Context, userId uuid.UUID, channel string) (*domain.Verification, error)
+	Save(ctx context.Context, v domain.Verification, resendAfter time.Time) (bool, error)
+	Delete(ctx context.Context, userId uuid.UUID, channel string) error
+	Confirm(ctx context.Context, userId uuid.UUID, channel string, check func(domain.Verification) error) error
 }
 
 // All service repositories
 type Repositories struct {
-	Account AccountRepository
+	Account      AccountRepository
+	Webhook      WebhookRepository
+	Verification VerificationRepository
 	// ...
 }
 
 func NewRepositories(db *sqlx.DB) *Repositories {
 	return &Repositories{
-		Account: storage.NewAccountRepository(db),
+		Account:      storage.NewAccountRepository(db),
+		Webhook:      storage.NewWebhookRepository(db),
+		Verification: storage.NewVerificationRepository(db),
 		// ...
 	}
 }
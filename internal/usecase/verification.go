@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
+	"github.com/WebChads/AccountService/internal/verification"
+	"github.com/go-playground/validator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type VerificationOptions struct {
+	CodeLength int
+	CodeTTL    time.Duration
+	// ResendCooldown is minimal interval between codes sent to one contact
+	ResendCooldown time.Duration
+	// MaxAttempts is number of wrong codes after which new code must be requested
+	MaxAttempts int
+}
+
+// VerificationUsecase confirms account contacts with codes sent to them
+type VerificationUsecase struct {
+	logger     *slog.Logger
+	accounts   AccountRepository
+	repository VerificationRepository
+	sender     verification.Sender
+	hasher     *verification.Hasher
+	validate   *validator.Validate
+	opts       VerificationOptions
+}
+
+func NewVerificationUsecase(accounts AccountRepository, r VerificationRepository, sender verification.Sender,
+	hasher *verification.Hasher, l *slog.Logger, opts VerificationOptions) *VerificationUsecase {
+	if opts.CodeLength <= 0 {
+		opts.CodeLength = 6
+	}
+	if opts.CodeTTL <= 0 {
+		opts.CodeTTL = 10 * time.Minute
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+
+	return &VerificationUsecase{
+		logger:     l,
+		accounts:   accounts,
+		repository: r,
+		sender:     sender,
+		hasher:     hasher,
+		validate:   validation.New(),
+		opts:       opts,
+	}
+}
+
+func (u *VerificationUsecase) log(ctx context.Context) *slog.Logger {
+	return slogerr.FromContext(ctx, u.logger)
+}
+
+// ResendCooldown returns minimal interval between codes sent to one contact
+func (u *VerificationUsecase) ResendCooldown() time.Duration {
+	return u.opts.ResendCooldown
+}
+
+// Send sends new code to account contact, previous code of channel becomes void
+func (u *VerificationUsecase) Send(ctx context.Context, req dtos.SendVerificationRequest) (*domain.Verification, error) {
+	ctx, span := tracer.Start(ctx, "VerificationUsecase.Send")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", req.UserId.String()),
+		attribute.String("verification.channel", req.Channel),
+	)
+
+	if err := u.validate.Struct(req); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	account, err := u.accounts.Select(ctx, req.UserId)
+	if err != nil {
+		u.log(ctx).Error("get account", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	contact, err := account.Contact(req.Channel)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if contact.Value == "" {
+		span.SetStatus(codes.Error, domain.ErrContactNotSet.Error())
+		return nil, domain.ErrContactNotSet
+	}
+	if contact.Verified() {
+		span.SetStatus(codes.Error, domain.ErrContactVerified.Error())
+		return nil, domain.ErrContactVerified
+	}
+
+	code, err := verification.GenerateCode(u.opts.CodeLength)
+	if err != nil {
+		u.log(ctx).Error("generate verification code", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+	v := domain.Verification{
+		UserId:    req.UserId,
+		Channel:   req.Channel,
+		Target:    contact.Value,
+		CodeHash:  u.hasher.Hash(req.UserId, req.Channel, contact.Value, code),
+		ExpiresAt: now.Add(u.opts.CodeTTL),
+		SentAt:    now,
+	}
+
+	// Cooldown is checked by storage, so concurrent requests send one code
+	saved, err := u.repository.Save(ctx, v, now.Add(-u.opts.ResendCooldown))
+	if err != nil {
+		u.log(ctx).Error("save verification", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if !saved {
+		err = u.cooldownError(ctx, req, now)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err = u.sender.Send(ctx, verification.NewMessage(v, code, req.Language)); err != nil {
+		u.log(ctx).Error("send verification code", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+
+		// Code never reached user, so it must not hold cooldown
+		if deleteErr := u.repository.Delete(ctx, req.UserId, req.Channel); deleteErr != nil {
+			u.log(ctx).Error("delete verification", slogerr.Error(deleteErr))
+		}
+
+		return nil, errors.New("failed to send verification code: " + err.Error())
+	}
+
+	u.log(ctx).Info("audit",
+		"action", "verification.send",
+		"account_id", req.UserId.String(),
+		"channel", req.Channel,
+		"actor", actorFromContext(ctx),
+	)
+
+	return &v, nil
+}
+
+// cooldownError returns time left until code may be sent again
+func (u *VerificationUsecase) cooldownError(ctx context.Context, req dtos.SendVerificationRequest,
+	now time.Time) error {
+	retryAfter := u.opts.ResendCooldown
+
+	// Verification may be confirmed or removed meanwhile, full cooldown is reported then
+	v, err := u.repository.Get(ctx, req.UserId, req.Channel)
+	if err == nil {
+		retryAfter = v.ResendAt(u.opts.ResendCooldown).Sub(now)
+	}
+
+	return &domain.ResendCooldownError{RetryAfter: max(retryAfter, time.Second)}
+}
+
+// Confirm marks account contact verified if code matches the last one sent
+func (u *VerificationUsecase) Confirm(ctx context.Context, req dtos.ConfirmVerificationRequest) error {
+	ctx, span := tracer.Start(ctx, "VerificationUsecase.Confirm")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", req.UserId.String()),
+		attribute.String("verification.channel", req.Channel),
+	)
+
+	if err := u.validate.Struct(req); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	err := u.repository.Confirm(ctx, req.UserId, req.Channel, func(v domain.Verification) error {
+		if err := v.Check(time.Now(), u.opts.MaxAttempts); err != nil {
+			return err
+		}
+		if !u.hasher.Verify(v, req.Code) {
+			return domain.ErrInvalidCode
+		}
+
+		return nil
+	})
+	if err != nil {
+		u.log(ctx).Warn("confirm verification", "channel", req.Channel, slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	u.log(ctx).Info("audit",
+		"action", "verification.confirm",
+		"account_id", req.UserId.String(),
+		"channel", req.Channel,
+		"actor", actorFromContext(ctx),
+	)
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"github.com/WebChads/AccountService/internal/pkg/validation"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const defaultDeliveriesLimit = 50
+
+type WebhookUsecase struct {
+	logger     *slog.Logger
+	repository WebhookRepository
+	validate   *validator.Validate
+}
+
+func NewWebhookUsecase(r WebhookRepository, l *slog.Logger) *WebhookUsecase {
+	return &WebhookUsecase{
+		logger:     l,
+		repository: r,
+		validate:   validation.New(),
+	}
+}
+
+func (u *WebhookUsecase) log(ctx context.Context) *slog.Logger {
+	return slogerr.FromContext(ctx, u.logger)
+}
+
+func (u *WebhookUsecase) CreateSubscription(ctx context.Context,
+	req dtos.CreateWebhookRequest) (*dtos.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.CreateSubscription")
+	defer span.End()
+
+	if err := u.validate.Struct(req); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	subscription, err := u.repository.CreateSubscription(ctx, req)
+	if err != nil {
+		u.log(ctx).Error("create webhook", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	u.log(ctx).Info("audit",
+		"action", "webhook.create",
+		"webhook_id", subscription.ID.String(),
+		"actor", actorFromContext(ctx),
+	)
+
+	return subscription, nil
+}
+
+func (u *WebhookUsecase) ListSubscriptions(ctx context.Context) ([]dtos.WebhookSubscription, error) {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.ListSubscriptions")
+	defer span.End()
+
+	subscriptions, err := u.repository.ListSubscriptions(ctx)
+	if err != nil {
+		u.log(ctx).Error("list webhooks", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (u *WebhookUsecase) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.DeleteSubscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook_id", id.String()))
+
+	if err := u.repository.DeleteSubscription(ctx, id); err != nil {
+		u.log(ctx).Error("delete webhook", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	u.log(ctx).Info("audit",
+		"action", "webhook.delete",
+		"webhook_id", id.String(),
+		"actor", actorFromContext(ctx),
+	)
+
+	return nil
+}
+
+func (u *WebhookUsecase) ListDeliveries(ctx context.Context,
+	req dtos.ListWebhookDeliveriesRequest) ([]dtos.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.ListDeliveries")
+	defer span.End()
+
+	if err := u.validate.Struct(req); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultDeliveriesLimit
+	}
+
+	deliveries, err := u.repository.ListDeliveries(ctx, req)
+	if err != nil {
+		u.log(ctx).Error("list webhook deliveries", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (u *WebhookUsecase) GetDelivery(ctx context.Context, id int64) (*dtos.WebhookDelivery, error) {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.GetDelivery")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("delivery_id", id))
+
+	delivery, err := u.repository.GetDelivery(ctx, id)
+	if err != nil {
+		u.log(ctx).Error("get webhook delivery", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// ReplayDelivery resends delivery regardless of its status
+func (u *WebhookUsecase) ReplayDelivery(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "WebhookUsecase.ReplayDelivery")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("delivery_id", id))
+
+	if err := u.repository.ReplayDelivery(ctx, id); err != nil {
+		u.log(ctx).Error("replay webhook delivery", slogerr.Error(err))
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	u.log(ctx).Info("audit",
+		"action", "webhook.replay",
+		"delivery_id", id,
+		"actor", actorFromContext(ctx),
+	)
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package verification
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/google/uuid"
+)
+
+func TestGenerateCodeIsZeroPadded(t *testing.T) {
+	padded := false
+	for range 1000 {
+		code, err := GenerateCode(2)
+		if err != nil {
+			t.Fatalf("GenerateCode() error = %v", err)
+		}
+		if len(code) != 2 || strings.Trim(code, "0123456789") != "" {
+			t.Fatalf("GenerateCode() = %q, want 2 digits", code)
+		}
+		padded = padded || code[0] == '0'
+	}
+
+	// Codes below 10 come one time in ten
+	if !padded {
+		t.Fatal("no code starts with 0 in 1000 attempts")
+	}
+}
+
+func TestHasherVerify(t *testing.T) {
+	h := NewHasher("secret")
+	userId := uuid.New()
+
+	v := domain.Verification{
+		UserId:    userId,
+		Channel:   domain.ChannelEmail,
+		Target:    "ivan@example.com",
+		CodeHash:  h.Hash(userId, domain.ChannelEmail, "ivan@example.com", "123456"),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	if !h.Verify(v, "123456") {
+		t.Error("Verify() = false for sent code")
+	}
+	if h.Verify(v, "654321") {
+		t.Error("Verify() = true for wrong code")
+	}
+
+	// Code is void for other contact, user and secret
+	changed := v
+	changed.Target = "other@example.com"
+	if h.Verify(changed, "123456") {
+		t.Error("Verify() = true after contact change")
+	}
+	changed = v
+	changed.UserId = uuid.New()
+	if h.Verify(changed, "123456") {
+		t.Error("Verify() = true for other user")
+	}
+	if NewHasher("other secret").Verify(v, "123456") {
+		t.Error("Verify() = true with other secret")
+	}
+}
@@ -0,0 +1,123 @@
+package verification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/config"
+	"github.com/WebChads/AccountService/internal/models/domain"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+)
+
+// Sender delivers verification codes to email or phone
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Message is verification code addressed to contact
+type Message struct {
+	Channel string `json:"channel"`
+	To      string `json:"to"`
+	// Text is ready to send message in requested language
+	Text string `json:"text"`
+	// Code is passed separately, e.g. for templates of email provider
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// texts are message templates by language, code and minutes it is valid
+var texts = map[string]string{
+	"en": "Your verification code is %s. It expires in %d min.",
+	"ru": "Ваш код подтверждения: %s. Он действует %d мин.",
+}
+
+// NewMessage creates message of code sent for verification with text
+// in lang, English if it is not supported
+func NewMessage(v domain.Verification, code, lang string) Message {
+	text, ok := texts[lang]
+	if !ok {
+		text = texts["en"]
+	}
+
+	ttl := v.ExpiresAt.Sub(v.SentAt)
+
+	return Message{
+		Channel:   v.Channel,
+		To:        v.Target,
+		Text:      fmt.Sprintf(text, code, int(math.Ceil(ttl.Minutes()))),
+		Code:      code,
+		ExpiresAt: v.ExpiresAt,
+	}
+}
+
+// LogSender writes messages to service log, for local runs only as codes are logged
+type LogSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(l *slog.Logger) *LogSender {
+	return &LogSender{logger: l}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg Message) error {
+	slogerr.FromContext(ctx, s.logger).Info("verification message",
+		"channel", msg.Channel,
+		"to", msg.To,
+		"text", msg.Text,
+	)
+
+	return nil
+}
+
+// FileSender appends messages to file as NDJSON, e.g. for local mail catcher
+type FileSender struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func NewFileSender(path string) (*FileSender, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, errors.New("failed to open verification file: " + err.Error())
+	}
+
+	return &FileSender{file: file, encoder: json.NewEncoder(file)}, nil
+}
+
+func (s *FileSender) Send(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.encoder.Encode(msg)
+}
+
+func (s *FileSender) Close() error {
+	return s.file.Close()
+}
+
+// NewSenderFromConfig creates sender selected in config.
+// Returned close function releases sender resources.
+func NewSenderFromConfig(cfg config.VerificationConfig, l *slog.Logger) (Sender, func() error, error) {
+	noop := func() error { return nil }
+
+	switch cfg.Sender {
+	case "log":
+		return NewLogSender(l), noop, nil
+	case "file":
+		sender, err := NewFileSender(cfg.FilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sender, sender.Close, nil
+	}
+
+	return nil, nil, errors.New("unknown verification sender: " + cfg.Sender)
+}
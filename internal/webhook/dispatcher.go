@@ -0,0 +1,209 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/models/events"
+	slogerr "github.com/WebChads/AccountService/internal/pkg/logger"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const userAgent = "AccountService-Webhook/1.0"
+
+type Repository interface {
+	Enqueue(ctx context.Context, msg events.Message) error
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.PendingDelivery, error)
+	RecordAttempt(ctx context.Context, attempt domain.DeliveryAttempt) error
+}
+
+type DispatcherOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// Timeout limits single HTTP attempt
+	Timeout time.Duration
+	// MaxAttempts is number of tries before delivery is marked failed
+	MaxAttempts int
+	// Retry delay doubles from MinBackoff up to MaxBackoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Dispatcher sends pending webhook deliveries
+type Dispatcher struct {
+	repository Repository
+	client     *http.Client
+	logger     *slog.Logger
+	opts       DispatcherOptions
+}
+
+func NewDispatcher(r Repository, l *slog.Logger, opts DispatcherOptions) *Dispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 50
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 8
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = 10 * time.Second
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = time.Hour
+	}
+
+	client := &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+		Timeout:   opts.Timeout,
+		// Redirect response is treated as failure, target must be registered explicitly
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return &Dispatcher{
+		repository: r,
+		client:     client,
+		logger:     l,
+		opts:       opts,
+	}
+}
+
+// Run sends deliveries until ctx is canceled
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.logger.Info("webhook dispatcher started")
+	defer d.logger.Info("webhook dispatcher stopped")
+
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain queue while full batches are returned
+		for {
+			sent, err := d.dispatchBatch(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					d.logger.Error("webhook dispatch failed", slogerr.Error(err))
+				}
+				break
+			}
+			if sent < d.opts.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	// Lease outlives all HTTP calls of the batch, so no other dispatcher takes them
+	lease := d.opts.Timeout*time.Duration(d.opts.BatchSize) + time.Minute
+
+	deliveries, err := d.repository.ClaimDeliveries(ctx, d.opts.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.repository.RecordAttempt(ctx, d.deliver(ctx, delivery)); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// deliver makes single HTTP attempt and returns its result
+func (d *Dispatcher) deliver(ctx context.Context, delivery domain.PendingDelivery) domain.DeliveryAttempt {
+	attempt := domain.DeliveryAttempt{
+		DeliveryId: delivery.ID,
+		Status:     dtos.WebhookDeliverySucceeded,
+	}
+
+	start := time.Now()
+	attempt.StatusCode, attempt.Error = d.send(ctx, delivery)
+	attempt.Duration = time.Since(start)
+
+	if attempt.Error == "" {
+		return attempt
+	}
+
+	retries := delivery.Retries + 1
+	if retries >= d.opts.MaxAttempts {
+		attempt.Status = dtos.WebhookDeliveryFailed
+	} else {
+		attempt.Status = dtos.WebhookDeliveryPending
+		attempt.NextAttemptAt = time.Now().Add(d.backoff(retries))
+	}
+
+	d.logger.Warn("webhook delivery failed",
+		"delivery_id", delivery.ID,
+		"event_type", delivery.EventType,
+		"status_code", attempt.StatusCode,
+		"retries", retries,
+		"status", attempt.Status,
+		"error", attempt.Error,
+	)
+
+	return attempt
+}
+
+// send posts signed payload, non-empty error means attempt failed
+func (d *Dispatcher) send(ctx context.Context, delivery domain.PendingDelivery) (int, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "failed to create request: " + err.Error()
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderDeliveryId, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, "failed to send request: " + err.Error()
+	}
+	defer resp.Body.Close()
+
+	// Drain body so connection is reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, ""
+}
+
+func (d *Dispatcher) backoff(retries int) time.Duration {
+	delay := float64(d.opts.MinBackoff) * math.Pow(2, float64(retries-1))
+	if delay > float64(d.opts.MaxBackoff) {
+		return d.opts.MaxBackoff
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,347 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/WebChads/AccountService/internal/models/domain"
+	"github.com/WebChads/AccountService/internal/models/dtos"
+	"github.com/WebChads/AccountService/internal/models/events"
+)
+
+const testSecret = "test-secret"
+
+// memoryDelivery mirrors webhook_deliveries row
+type memoryDelivery struct {
+	url           string
+	status        string
+	attempts      int
+	retryBase     int
+	nextAttemptAt time.Time
+	statusCodes   []int
+}
+
+// memoryRepository keeps deliveries the way WebhookRepository does:
+// retries of claimed delivery are attempts made since creation or last replay
+type memoryRepository struct {
+	mu         sync.Mutex
+	deliveries map[int64]*memoryDelivery
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{deliveries: make(map[int64]*memoryDelivery)}
+}
+
+func (r *memoryRepository) add(id int64, url string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries[id] = &memoryDelivery{url: url, status: dtos.WebhookDeliveryPending}
+}
+
+func (r *memoryRepository) get(id int64) memoryDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return *r.deliveries[id]
+}
+
+// makeDue skips backoff of pending delivery
+func (r *memoryRepository) makeDue(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deliveries[id].nextAttemptAt = time.Time{}
+}
+
+// replay does what WebhookRepository.ReplayDelivery query does
+func (r *memoryRepository) replay(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.deliveries[id]
+	d.status = dtos.WebhookDeliveryPending
+	d.retryBase = d.attempts
+	d.nextAttemptAt = time.Time{}
+}
+
+func (r *memoryRepository) Enqueue(context.Context, events.Message) error {
+	return nil
+}
+
+func (r *memoryRepository) ClaimDeliveries(_ context.Context, limit int,
+	lease time.Duration) ([]domain.PendingDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	var claimed []domain.PendingDelivery
+	for id, d := range r.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+		if d.status != dtos.WebhookDeliveryPending || d.nextAttemptAt.After(now) {
+			continue
+		}
+
+		d.nextAttemptAt = now.Add(lease)
+		claimed = append(claimed, domain.PendingDelivery{
+			ID:        id,
+			URL:       d.url,
+			Secret:    testSecret,
+			EventType: events.AccountCreated,
+			Payload:   json.RawMessage(`{"id":"event"}`),
+			Retries:   d.attempts - d.retryBase,
+		})
+	}
+
+	return claimed, nil
+}
+
+func (r *memoryRepository) RecordAttempt(_ context.Context, a domain.DeliveryAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d := r.deliveries[a.DeliveryId]
+	d.attempts++
+	d.status = a.Status
+	d.nextAttemptAt = a.NextAttemptAt
+	d.statusCodes = append(d.statusCodes, a.StatusCode)
+
+	return nil
+}
+
+func newTestDispatcher(r Repository, maxAttempts int) *Dispatcher {
+	return NewDispatcher(r, slog.New(slog.NewTextHandler(io.Discard, nil)), DispatcherOptions{
+		MaxAttempts: maxAttempts,
+		MinBackoff:  time.Minute,
+		MaxBackoff:  4 * time.Minute,
+	})
+}
+
+// dispatch runs one batch and fails test on repository error
+func dispatch(t *testing.T, d *Dispatcher) int {
+	t.Helper()
+
+	sent, err := d.dispatchBatch(context.Background())
+	if err != nil {
+		t.Fatalf("dispatchBatch() error = %v", err)
+	}
+
+	return sent
+}
+
+func TestDispatcherDeliversSignedRequest(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		err := Verify(testSecret, r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderSignature),
+			body, time.Minute, time.Now())
+		if err != nil {
+			t.Errorf("Verify() error = %v", err)
+		}
+
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := newMemoryRepository()
+	repo.add(1, server.URL)
+
+	if sent := dispatch(t, newTestDispatcher(repo, 3)); sent != 1 {
+		t.Fatalf("dispatchBatch() sent = %d, want 1", sent)
+	}
+
+	r := <-received
+	if got := r.Header.Get(HeaderDeliveryId); got != "1" {
+		t.Errorf("%s = %q, want %q", HeaderDeliveryId, got, "1")
+	}
+	if got := r.Header.Get(HeaderEvent); got != events.AccountCreated {
+		t.Errorf("%s = %q, want %q", HeaderEvent, got, events.AccountCreated)
+	}
+
+	d := repo.get(1)
+	if d.status != dtos.WebhookDeliverySucceeded || d.attempts != 1 {
+		t.Fatalf("delivery status = %s, attempts = %d, want succeeded after 1 attempt", d.status, d.attempts)
+	}
+
+	// Succeeded delivery is not sent again
+	if sent := dispatch(t, newTestDispatcher(repo, 3)); sent != 0 {
+		t.Fatalf("dispatchBatch() sent = %d after success, want 0", sent)
+	}
+}
+
+func TestDispatcherRetriesFailedAttempt(t *testing.T) {
+	var redirected atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/client-error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/target", http.StatusFound)
+	})
+	mux.HandleFunc("/target", func(w http.ResponseWriter, r *http.Request) {
+		redirected.Store(true)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		statusCode int
+	}{
+		{name: "server error", url: server.URL + "/error", statusCode: http.StatusInternalServerError},
+		{name: "client error", url: server.URL + "/client-error", statusCode: http.StatusBadRequest},
+		{name: "redirect", url: server.URL + "/redirect", statusCode: http.StatusFound},
+		{name: "unreachable", url: "http://127.0.0.1:1/", statusCode: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepository()
+			repo.add(1, tt.url)
+
+			start := time.Now()
+			dispatch(t, newTestDispatcher(repo, 3))
+
+			d := repo.get(1)
+			if d.status != dtos.WebhookDeliveryPending {
+				t.Fatalf("delivery status = %s, want %s", d.status, dtos.WebhookDeliveryPending)
+			}
+			if d.statusCodes[0] != tt.statusCode {
+				t.Errorf("status code = %d, want %d", d.statusCodes[0], tt.statusCode)
+			}
+			// First retry waits MinBackoff
+			if wait := d.nextAttemptAt.Sub(start); wait < time.Minute || wait > time.Minute+5*time.Second {
+				t.Errorf("next attempt in %s, want about 1m", wait)
+			}
+
+			// Delivery is not due until backoff passes
+			if sent := dispatch(t, newTestDispatcher(repo, 3)); sent != 0 {
+				t.Fatalf("dispatchBatch() sent = %d during backoff, want 0", sent)
+			}
+		})
+	}
+
+	if redirected.Load() {
+		t.Fatal("redirect was followed")
+	}
+}
+
+func TestDispatcherBackoff(t *testing.T) {
+	d := newTestDispatcher(newMemoryRepository(), 8)
+
+	want := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute}
+	for i, w := range want {
+		if got := d.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestDispatcherFailsAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	const maxAttempts = 3
+
+	repo := newMemoryRepository()
+	repo.add(1, server.URL)
+	dispatcher := newTestDispatcher(repo, maxAttempts)
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		dispatch(t, dispatcher)
+
+		want := dtos.WebhookDeliveryPending
+		if attempt == maxAttempts {
+			want = dtos.WebhookDeliveryFailed
+		}
+		if d := repo.get(1); d.status != want {
+			t.Fatalf("after attempt %d status = %s, want %s", attempt, d.status, want)
+		}
+
+		repo.makeDue(1)
+	}
+
+	// Failed delivery is not retried
+	if sent := dispatch(t, dispatcher); sent != 0 {
+		t.Fatalf("dispatchBatch() sent = %d after failure, want 0", sent)
+	}
+	if d := repo.get(1); d.attempts != maxAttempts {
+		t.Fatalf("attempts = %d, want %d", d.attempts, maxAttempts)
+	}
+}
+
+func TestDispatcherReplayResetsRetryBudget(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		healthy bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !healthy {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	const maxAttempts = 2
+
+	repo := newMemoryRepository()
+	repo.add(1, server.URL)
+	dispatcher := newTestDispatcher(repo, maxAttempts)
+
+	for range maxAttempts {
+		dispatch(t, dispatcher)
+		repo.makeDue(1)
+	}
+	if d := repo.get(1); d.status != dtos.WebhookDeliveryFailed {
+		t.Fatalf("status = %s, want %s", d.status, dtos.WebhookDeliveryFailed)
+	}
+
+	// Replayed delivery gets full budget again instead of failing on next error
+	repo.replay(1)
+	start := time.Now()
+	dispatch(t, dispatcher)
+
+	d := repo.get(1)
+	if d.status != dtos.WebhookDeliveryPending {
+		t.Fatalf("status after replayed attempt = %s, want %s", d.status, dtos.WebhookDeliveryPending)
+	}
+	if wait := d.nextAttemptAt.Sub(start); wait > time.Minute+5*time.Second {
+		t.Errorf("next attempt in %s, want first retry backoff of 1m", wait)
+	}
+	// Attempt log keeps numbering across replay
+	if d.attempts != maxAttempts+1 {
+		t.Fatalf("attempts = %d, want %d", d.attempts, maxAttempts+1)
+	}
+
+	mu.Lock()
+	healthy = true
+	mu.Unlock()
+
+	repo.makeDue(1)
+	dispatch(t, dispatcher)
+
+	if d := repo.get(1); d.status != dtos.WebhookDeliverySucceeded {
+		t.Fatalf("status = %s, want %s", d.status, dtos.WebhookDeliverySucceeded)
+	}
+}
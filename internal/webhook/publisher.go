@@ -0,0 +1,20 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/WebChads/AccountService/internal/models/events"
+)
+
+// Enqueuer is outbox publisher which turns events into webhook deliveries
+type Enqueuer struct {
+	repository Repository
+}
+
+func NewEnqueuer(r Repository) *Enqueuer {
+	return &Enqueuer{repository: r}
+}
+
+func (e *Enqueuer) Publish(ctx context.Context, msg events.Message) error {
+	return e.repository.Enqueue(ctx, msg)
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSignVerify(t *testing.T) {
+	const (
+		secret    = "webhook-secret"
+		tolerance = 5 * time.Minute
+	)
+
+	body := []byte(`{"type":"account.created"}`)
+	sentAt := time.Unix(1_700_000_000, 0)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	signature := Sign(secret, sentAt.Unix(), body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		wantErr   bool
+	}{
+		{name: "valid", secret: secret, timestamp: timestamp, signature: signature, body: body, now: sentAt},
+		{name: "late within tolerance", secret: secret, timestamp: timestamp, signature: signature, body: body,
+			now: sentAt.Add(tolerance)},
+		{name: "clock skew within tolerance", secret: secret, timestamp: timestamp, signature: signature, body: body,
+			now: sentAt.Add(-tolerance)},
+		{name: "too late", secret: secret, timestamp: timestamp, signature: signature, body: body,
+			now: sentAt.Add(tolerance + time.Second), wantErr: true},
+		{name: "from future", secret: secret, timestamp: timestamp, signature: signature, body: body,
+			now: sentAt.Add(-tolerance - time.Second), wantErr: true},
+		{name: "wrong secret", secret: "other-secret", timestamp: timestamp, signature: signature, body: body,
+			now: sentAt, wantErr: true},
+		{name: "tampered body", secret: secret, timestamp: timestamp, signature: signature,
+			body: []byte(`{"type":"account.deleted"}`), now: sentAt, wantErr: true},
+		{name: "replayed with new timestamp", secret: secret, timestamp: strconv.FormatInt(sentAt.Unix()+60, 10),
+			signature: signature, body: body, now: sentAt, wantErr: true},
+		{name: "missing prefix", secret: secret, timestamp: timestamp, signature: signature[len(signaturePrefix):],
+			body: body, now: sentAt, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, tolerance, tt.now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyMalformedTimestamp(t *testing.T) {
+	err := Verify("secret", "not-a-number", "sha256=00", nil, time.Minute, time.Now())
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("Verify() error = %v, want %v", err, ErrInvalidSignature)
+	}
+}
+
+func TestSignIsDeterministic(t *testing.T) {
+	body := []byte("payload")
+
+	if Sign("secret", 1, body) != Sign("secret", 1, body) {
+		t.Fatal("Sign() returned different signatures for same input")
+	}
+	if Sign("secret", 1, body) == Sign("secret", 2, body) {
+		t.Fatal("Sign() does not depend on timestamp")
+	}
+}
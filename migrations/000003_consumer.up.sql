@@ -0,0 +1,20 @@
+-- Up migration: creates consumer bookkeeping tables
+
+-- Ids of handled inbound events, used for deduplication
+CREATE TABLE processed_events (
+    event_id VARCHAR(255) PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Inbound events which could not be processed
+CREATE TABLE dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    -- Raw payload, poison messages may be invalid JSON
+    payload TEXT,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    failed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
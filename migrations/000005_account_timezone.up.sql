@@ -0,0 +1,8 @@
+-- Up migration: adds account time zone and birthday lookup index
+
+-- IANA time zone name, NULL means UTC
+ALTER TABLE accounts ADD COLUMN timezone VARCHAR(64);
+
+-- Birthday key is month * 100 + day, e.g. 1231 for December 31
+CREATE INDEX idx_accounts_birthday
+    ON accounts (((EXTRACT(MONTH FROM birthdate) * 100 + EXTRACT(DAY FROM birthdate))::int));
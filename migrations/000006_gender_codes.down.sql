@@ -0,0 +1,3 @@
+ALTER TABLE accounts
+    DROP CONSTRAINT IF EXISTS accounts_gender_check,
+    ALTER COLUMN gender DROP NOT NULL;
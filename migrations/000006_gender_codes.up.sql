@@ -0,0 +1,16 @@
+-- Up migration: stores gender as canonical code
+
+-- Single letters were accepted before, known ones are mapped to codes,
+-- the rest become undisclosed
+UPDATE accounts SET gender = CASE
+        WHEN UPPER(gender) IN ('M', 'М') THEN 'M'
+        WHEN UPPER(gender) IN ('F', 'Ж') THEN 'F'
+        WHEN UPPER(gender) IN ('N', 'X') THEN 'N'
+        ELSE 'U'
+    END
+WHERE gender IS NULL OR gender NOT IN ('M', 'F', 'N', 'U');
+
+-- Codes are M (male), F (female), N (non-binary) and U (undisclosed)
+ALTER TABLE accounts
+    ALTER COLUMN gender SET NOT NULL,
+    ADD CONSTRAINT accounts_gender_check CHECK (gender IN ('M', 'F', 'N', 'U'));
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_accounts_firstname_key;
+DROP INDEX IF EXISTS idx_accounts_surname_key;
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS patronymic_key,
+    DROP COLUMN IF EXISTS surname_key,
+    DROP COLUMN IF EXISTS firstname_key;
@@ -0,0 +1,34 @@
+-- Up migration: adds transliterated name search keys
+
+-- Keys are lower case GOST 7.79-2000 system B transliteration without
+-- apostrophes, written by service on every name change
+ALTER TABLE accounts
+    ADD COLUMN firstname_key TEXT NOT NULL DEFAULT '',
+    ADD COLUMN surname_key TEXT NOT NULL DEFAULT '',
+    ADD COLUMN patronymic_key TEXT NOT NULL DEFAULT '';
+
+-- Same as domain.NameKey, used only to fill keys of existing accounts.
+-- normalize() requires PostgreSQL 13 and UTF8 database. lower() uses ICU
+-- collation, as database one may be C and leave Cyrillic as is.
+CREATE FUNCTION pg_temp.name_key(name TEXT) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE STRICT AS $$
+    SELECT translate(
+        replace(replace(replace(replace(replace(replace(
+        replace(replace(replace(replace(replace(
+            regexp_replace(
+                lower(btrim(regexp_replace(normalize(name, NFC), '\s+', ' ', 'g')) COLLATE "und-x-icu"),
+                'ц(?=[еиыйэёюяієїeiyj])', 'c', 'g'),
+            'щ', 'shh'), 'ё', 'yo'), 'ж', 'zh'), 'ц', 'cz'), 'ч', 'ch'), 'ш', 'sh'),
+            'ю', 'yu'), 'я', 'ya'), 'ї', 'yi'), 'є', 'ye'), 'х', 'x'),
+        'абвгдезийклмнопрстуфыэіґўъь''`',
+        'abvgdezijklmnoprstufyeigu')
+$$;
+
+UPDATE accounts SET
+    firstname_key = pg_temp.name_key(firstname),
+    surname_key = pg_temp.name_key(surname),
+    patronymic_key = COALESCE(pg_temp.name_key(patronymic), '');
+
+-- text_pattern_ops lets LIKE prefix match use index in any collation
+CREATE INDEX idx_accounts_surname_key ON accounts(surname_key text_pattern_ops);
+CREATE INDEX idx_accounts_firstname_key ON accounts(firstname_key);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_accounts_search_vector;
+DROP INDEX IF EXISTS idx_accounts_full_name_trgm;
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS full_name_key;
+-- Extension is kept, other objects may depend on it
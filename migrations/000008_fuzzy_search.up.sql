@@ -0,0 +1,15 @@
+-- Up migration: adds fuzzy full name search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Full name of transliterated keys, so typos match in either script
+ALTER TABLE accounts ADD COLUMN full_name_key TEXT
+    GENERATED ALWAYS AS (btrim(surname_key || ' ' || firstname_key || ' ' || patronymic_key)) STORED;
+
+-- Words of names in both original and Latin script
+ALTER TABLE accounts ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple',
+        surname || ' ' || firstname || ' ' || COALESCE(patronymic, '') || ' ' ||
+        surname_key || ' ' || firstname_key || ' ' || patronymic_key)) STORED;
+
+CREATE INDEX idx_accounts_full_name_trgm ON accounts USING GIN (full_name_key gin_trgm_ops);
+CREATE INDEX idx_accounts_search_vector ON accounts USING GIN (search_vector);
@@ -0,0 +1,6 @@
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS city,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS bio,
+    DROP COLUMN IF EXISTS display_name;
@@ -0,0 +1,9 @@
+-- Up migration: adds optional profile fields
+ALTER TABLE accounts
+    ADD COLUMN display_name VARCHAR(64),
+    ADD COLUMN bio VARCHAR(500),
+    -- BCP 47 language tag, e.g. ru-RU
+    ADD COLUMN locale VARCHAR(35),
+    -- ISO 3166-1 alpha-2 code, e.g. RU
+    ADD COLUMN country VARCHAR(2),
+    ADD COLUMN city VARCHAR(128);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS verification_codes;
+
+DROP INDEX IF EXISTS idx_accounts_verified_phone;
+DROP INDEX IF EXISTS idx_accounts_verified_email;
+
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS phone_verified_at,
+    DROP COLUMN IF EXISTS phone,
+    DROP COLUMN IF EXISTS email_verified_at,
+    DROP COLUMN IF EXISTS email;
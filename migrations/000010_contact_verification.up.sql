@@ -0,0 +1,25 @@
+-- Up migration: adds account contacts and their verification codes
+ALTER TABLE accounts
+    ADD COLUMN email VARCHAR(254),
+    ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE,
+    -- E.164 number, e.g. +79991234567
+    ADD COLUMN phone VARCHAR(16),
+    ADD COLUMN phone_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- Contact may be verified by one account only
+CREATE UNIQUE INDEX idx_accounts_verified_email ON accounts(email) WHERE email_verified_at IS NOT NULL;
+CREATE UNIQUE INDEX idx_accounts_verified_phone ON accounts(phone) WHERE phone_verified_at IS NOT NULL;
+
+-- Pending code per account contact, new code replaces previous one
+CREATE TABLE verification_codes (
+    user_id UUID NOT NULL,
+    channel VARCHAR(8) NOT NULL CHECK (channel IN ('email', 'phone')),
+    -- Contact value code was sent to
+    target VARCHAR(254) NOT NULL,
+    -- HMAC-SHA256 of code, code itself is not stored
+    code_hash BYTEA NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, channel)
+);
@@ -0,0 +1,2 @@
+-- Up migration: adds account avatar, JSON of thumbnail keys and URLs
+ALTER TABLE accounts ADD COLUMN avatar JSONB;
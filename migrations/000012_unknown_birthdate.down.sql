@@ -0,0 +1,2 @@
+UPDATE accounts SET birthdate = DATE '1900-01-01' WHERE birthdate IS NULL;
+ALTER TABLE accounts ALTER COLUMN birthdate SET NOT NULL;
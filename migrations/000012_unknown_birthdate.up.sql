@@ -0,0 +1,8 @@
+-- Up migration: birthdate is unknown for accounts provisioned from registration events
+ALTER TABLE accounts ALTER COLUMN birthdate DROP NOT NULL;
+
+-- Provisioned accounts used to get 1900-01-01 placeholder. Real users may be
+-- born that day, so only accounts with placeholder names and gender are reset.
+UPDATE accounts SET birthdate = NULL
+WHERE birthdate = DATE '1900-01-01'
+    AND firstname = '-' AND surname = '-' AND gender = 'U';
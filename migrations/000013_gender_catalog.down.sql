@@ -0,0 +1,6 @@
+-- Configured genders become undisclosed
+UPDATE accounts SET gender = 'U' WHERE gender NOT IN ('M', 'F', 'N', 'U');
+
+ALTER TABLE accounts
+    DROP CONSTRAINT accounts_gender_check,
+    ADD CONSTRAINT accounts_gender_check CHECK (gender IN ('M', 'F', 'N', 'U'));
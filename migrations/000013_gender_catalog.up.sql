@@ -0,0 +1,6 @@
+-- Up migration: gender catalog is configurable, codes are checked by service
+
+-- Any single latin capital letter may be configured as gender code
+ALTER TABLE accounts
+    DROP CONSTRAINT accounts_gender_check,
+    ADD CONSTRAINT accounts_gender_check CHECK (gender ~ '^[A-Z]$');
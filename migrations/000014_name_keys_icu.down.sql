@@ -0,0 +1,2 @@
+-- Refilled keys are correct for any collation, nothing to revert
+SELECT 1;
@@ -0,0 +1,26 @@
+-- Up migration: refills name keys lower-cased by database collation
+
+-- 000007 used lower() of database collation, which keeps Cyrillic upper
+-- case in C and POSIX databases. Keys differing from domain.NameKey are
+-- computed again with ICU collation.
+CREATE FUNCTION pg_temp.name_key(name TEXT) RETURNS TEXT
+LANGUAGE SQL IMMUTABLE STRICT AS $$
+    SELECT translate(
+        replace(replace(replace(replace(replace(replace(
+        replace(replace(replace(replace(replace(
+            regexp_replace(
+                lower(btrim(regexp_replace(normalize(name, NFC), '\s+', ' ', 'g')) COLLATE "und-x-icu"),
+                'ц(?=[еиыйэёюяієїeiyj])', 'c', 'g'),
+            'щ', 'shh'), 'ё', 'yo'), 'ж', 'zh'), 'ц', 'cz'), 'ч', 'ch'), 'ш', 'sh'),
+            'ю', 'yu'), 'я', 'ya'), 'ї', 'yi'), 'є', 'ye'), 'х', 'x'),
+        'абвгдезийклмнопрстуфыэіґўъь''`',
+        'abvgdezijklmnoprstufyeigu')
+$$;
+
+UPDATE accounts SET
+    firstname_key = pg_temp.name_key(firstname),
+    surname_key = pg_temp.name_key(surname),
+    patronymic_key = COALESCE(pg_temp.name_key(patronymic), '')
+WHERE firstname_key <> pg_temp.name_key(firstname)
+    OR surname_key <> pg_temp.name_key(surname)
+    OR patronymic_key <> COALESCE(pg_temp.name_key(patronymic), '');
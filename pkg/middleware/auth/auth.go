@@ -10,7 +10,9 @@ import (
 
 	"io"
 
+	response "github.com/WebChads/AccountService/internal/pkg/api"
 	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 type Middleware struct {
@@ -21,7 +23,8 @@ type Middleware struct {
 func NewMiddleware(authServiceUrl string) *Middleware {
 	return &Middleware{
 		AuthServiceUrl: authServiceUrl,
-		client:         &http.Client{},
+		// Transport starts client span and injects W3C trace context
+		client: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
 	}
 }
 
@@ -34,7 +37,7 @@ func (m *Middleware) Handler(next http.Handler) http.Handler {
 		// 1. Извлекаем токен из заголовка
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, "Authorization header required", http.StatusUnauthorized)
+			response.JSON(w, http.StatusUnauthorized, "Authorization header required")
 			return
 		}
 
@@ -48,53 +51,61 @@ func (m *Middleware) Handler(next http.Handler) http.Handler {
 
 		// 3. Отправляем запрос в auth-сервис для валидации
 		reqBody, _ := json.Marshal(map[string]string{"token": tokenString})
-		resp, err := m.client.Post(
+		req, err := http.NewRequestWithContext(
+			r.Context(),
+			http.MethodPost,
 			"http://"+m.AuthServiceUrl+"/api/v1/auth/validate-token",
-			"application/json",
 			bytes.NewBuffer(reqBody),
 		)
 		if err != nil {
-			http.Error(w, "Auth service unavailable", http.StatusServiceUnavailable)
+			response.JSON(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := m.client.Do(req)
+		if err != nil {
+			response.JSON(w, http.StatusServiceUnavailable, "Auth service unavailable")
 			return
 		}
 		defer resp.Body.Close()
 
 		b, err := io.ReadAll(resp.Body)
 		if err != nil {
-			http.Error(w, err.Error(), 500)
+			response.JSON(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
 		var responseDto AuthServiceResponseDto
 		err = json.Unmarshal(b, &responseDto)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			response.JSON(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
 		if resp.StatusCode != http.StatusOK || !responseDto.IsValid {
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			response.JSON(w, http.StatusUnauthorized, "Unauthorized")
 			return
 		}
 
 		// 4. Парсим JWT (без валидации, так как auth-сервис уже проверил)
 		token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
 		if err != nil {
-			http.Error(w, "Invalid token format", http.StatusUnauthorized)
+			response.JSON(w, http.StatusUnauthorized, "Invalid token format")
 			return
 		}
 
 		// 5. Извлекаем claims
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			http.Error(w, "Invalid token claims", http.StatusUnauthorized)
+			response.JSON(w, http.StatusUnauthorized, "Invalid token claims")
 			return
 		}
 
 		// 6. Достаем user_id и user_role
 		userID, ok := claims["user_id"].(string)
 		if !ok || userID == "" {
-			http.Error(w, "Missing user_id in token", http.StatusUnauthorized)
+			response.JSON(w, http.StatusUnauthorized, "Missing user_id in token")
 			return
 		}
 